@@ -12,15 +12,22 @@ type Range struct {
 	End   Position `json:"end"`
 }
 
+// related is a secondary location for a finding.
+type Related struct {
+	Message string `json:"message"`
+	Range   Range  `json:"range"`
+}
+
 // diagnostic is one finding.
 type Diagnostic struct {
-	RuleID      string   `json:"rule_id"`
-	Severity    string   `json:"severity"`
-	Message     string   `json:"message"`
-	Explanation string   `json:"explanation,omitempty"`
-	Range       Range    `json:"range"`
-	Tags        []string `json:"tags,omitempty"`
-	DocsURL     string   `json:"docs_url,omitempty"`
+	RuleID      string    `json:"rule_id"`
+	Severity    string    `json:"severity"`
+	Message     string    `json:"message"`
+	Explanation string    `json:"explanation,omitempty"`
+	Range       Range     `json:"range"`
+	Related     []Related `json:"related,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	DocsURL     string    `json:"docs_url,omitempty"`
 }
 
 // stats holds runtime metrics.
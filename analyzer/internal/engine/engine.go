@@ -9,6 +9,7 @@ import (
 	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
 	"github.com/barthollomew/check-this.nvim/analyzer/internal/rules"
 	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+	sitter "github.com/smacker/go-tree-sitter"
 )
 
 // engine runs parse, rules, suppressions.
@@ -20,10 +21,32 @@ type Engine struct {
 func NewEngine() Engine {
 	return Engine{
 		rules: []rules.Rule{
+			rules.NewAsyncLeakedTimer(),
+			rules.NewConcurrencyUnsynchronisedMutation(),
+			rules.NewConcurrencyWorkerLifecycle(),
 			rules.NewErrorsSwallowed(),
+			rules.NewErrorsUnguardedParse(),
+			rules.NewErrorsUnhandledEmitter(),
+			rules.NewNetCallInLoop(),
 			rules.NewNetNoTimeout(),
+			rules.NewNetTLSUnverified(),
+			rules.NewNetUncheckedStatus(),
+			rules.NewQueueConsumerSafety(),
+			rules.NewReliabilityAssertValidation(),
+			rules.NewReliabilityHardExit(),
+			rules.NewResourceLeak(),
+			rules.NewResourceUnboundedRead(),
+			rules.NewRetryLibraryConfig(),
+			rules.NewRetryNoJitter(),
+			rules.NewRetryNonIdempotent(),
 			rules.NewRetryUnbounded(),
+			rules.NewSecurityDynamicExec(),
+			rules.NewSecurityHardcodedSecret(),
+			rules.NewSecurityInjection(),
 			rules.NewStateGlobalMutable(),
+			rules.NewStateMutableDefault(),
+			rules.NewStateUnboundedCache(),
+			rules.NewTimeNaiveDatetime(),
 		},
 	}
 }
@@ -79,6 +102,13 @@ func (e Engine) Analyze(input AnalyzeInput) (diagnostic.Output, error) {
 
 	suppressions := collectSuppressions(input.Source)
 	analyzeStart := time.Now()
+	ctx := rules.Context{
+		Language: input.Lang,
+		Path:     input.Path,
+		Root:     root,
+		Source:   input.Source,
+		Handlers: rules.FindHandlers(input.Lang, input.Path, root, input.Source),
+	}
 	for _, rule := range e.rules {
 		if !input.Config.RuleEnabled(rule.ID()) {
 			continue
@@ -87,11 +117,7 @@ func (e Engine) Analyze(input AnalyzeInput) (diagnostic.Output, error) {
 			continue
 		}
 
-		diags, err := runRule(rule, rules.Context{
-			Language: input.Lang,
-			Root:     root,
-			Source:   input.Source,
-		})
+		diags, err := runRule(rule, ctx)
 		if err != nil {
 			out.Diagnostics = append(out.Diagnostics, diagnostic.Diagnostic{
 				RuleID:   fmt.Sprintf("internal.%s", rule.ID()),
@@ -116,10 +142,15 @@ func (e Engine) Analyze(input AnalyzeInput) (diagnostic.Output, error) {
 			if d.Severity == "" {
 				d.Severity = rule.Meta().DefaultSeverity
 			}
-			d.Severity = input.Config.RuleSeverity(rule.ID(), d.Severity)
 			if len(d.Tags) == 0 {
 				d.Tags = rule.Meta().Tags
 			}
+			// findings in request handlers run on every request.
+			if insideHandler(ctx, d.Range) {
+				d.Severity = escalate(d.Severity)
+				d.Tags = append(append([]string{}, d.Tags...), "request-path")
+			}
+			d.Severity = input.Config.RuleSeverity(rule.ID(), d.Severity)
 			out.Diagnostics = append(out.Diagnostics, d)
 		}
 		out.Stats.RulesRun++
@@ -136,6 +167,30 @@ func runRule(rule rules.Rule, ctx rules.Context) ([]diagnostic.Diagnostic, error
 	return diags, nil
 }
 
+// insidehandler maps a diagnostic back to its node and asks the context
+// for the enclosing handler.
+func insideHandler(ctx rules.Context, r diagnostic.Range) bool {
+	if len(ctx.Handlers) == 0 {
+		return false
+	}
+	start := sitter.Point{Row: uint32(r.Start.Line), Column: uint32(r.Start.Col)}
+	end := sitter.Point{Row: uint32(r.End.Line), Column: uint32(r.End.Col)}
+	return ctx.EnclosingHandler(ctx.Root.NamedDescendantForPointRange(start, end)) != nil
+}
+
+// escalate raises severity one step; error stays error.
+func escalate(severity string) string {
+	switch severity {
+	case "hint":
+		return "info"
+	case "info":
+		return "warning"
+	case "warning", "warn":
+		return "error"
+	}
+	return severity
+}
+
 func shouldSuppress(s map[string]map[int]struct{}, d diagnostic.Diagnostic) bool {
 	lines, ok := s[d.RuleID]
 	if ok {
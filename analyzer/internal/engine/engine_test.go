@@ -83,3 +83,52 @@ func TestAnalyzeSeverityOverride(t *testing.T) {
 		t.Fatalf("expected severity override to apply, got %s", out.Diagnostics[0].Severity)
 	}
 }
+
+func TestAnalyzeEscalatesInsideHandler(t *testing.T) {
+	src := `const express = require("express");
+const app = express();
+
+app.get("/users", async (req, res) => {
+  const r = await fetch("/api/users");
+  res.json(await r.json());
+});
+
+fetch("/api/warmup");
+`
+	input := AnalyzeInput{
+		Path:    "server.js",
+		Lang:    "javascript",
+		Source:  []byte(src),
+		Config:  config.Config{},
+		Version: "1.0",
+	}
+	out, err := NewEngine().Analyze(input)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	var inside, outside int
+	for _, d := range out.Diagnostics {
+		if d.RuleID != "net.no_timeout" {
+			continue
+		}
+		tagged := false
+		for _, tag := range d.Tags {
+			tagged = tagged || tag == "request-path"
+		}
+		switch d.Range.Start.Line {
+		case 4:
+			inside++
+			if d.Severity != "warning" || !tagged {
+				t.Fatalf("expected escalated, tagged diagnostic in handler, got %s %v", d.Severity, d.Tags)
+			}
+		case 8:
+			outside++
+			if d.Severity != "info" || tagged {
+				t.Fatalf("expected untouched diagnostic outside handler, got %s %v", d.Severity, d.Tags)
+			}
+		}
+	}
+	if inside != 1 || outside != 1 {
+		t.Fatalf("expected one net.no_timeout inside and one outside, got %d/%d", inside, outside)
+	}
+}
@@ -0,0 +1,280 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type AsyncLeakedTimer struct{}
+
+// newasyncleakedtimer builds rule.
+func NewAsyncLeakedTimer() Rule { return AsyncLeakedTimer{} }
+
+func (AsyncLeakedTimer) ID() string { return "async.leaked_timer" }
+
+func (AsyncLeakedTimer) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"async", "resources"},
+		Short:           "Timer or task is never cleared",
+		Long:            "Intervals, self-rescheduling timers and fire-and-forget tasks keep running and hold memory after their owner is gone.",
+	}
+}
+
+func (AsyncLeakedTimer) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r AsyncLeakedTimer) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r AsyncLeakedTimer) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			switch {
+			case name == "threading.Timer":
+				handle := timerHandle(n, ctx.Source)
+				if reschedulesSelf(n, positionalArgument(n, 1), ctx.Source) && !clearedSomewhere(ctx.Root, handle, []string{handle + ".cancel"}, ctx.Source) {
+					diags = append(diags, r.diag(n, "threading.Timer reschedules itself with no stop condition",
+						"Each run starts a new Timer, so the loop never ends and keeps the process alive. Check a stop flag before rescheduling and keep the Timer so it can be cancel()ed."))
+				}
+			case name == "asyncio.create_task" || name == "asyncio.ensure_future" || (strings.HasSuffix(name, ".create_task") && !isTaskGroup(name)):
+				if d, ok := r.checkTask(n, name, ctx.Source); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r AsyncLeakedTimer) runJS(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := strings.TrimPrefix(strings.TrimPrefix(calleeName(n, ctx.Source), "window."), "globalThis.")
+			handle := timerHandle(n, ctx.Source)
+			switch name {
+			case "setInterval":
+				switch {
+				case handleEscapes(n):
+				case handle == "":
+					diags = append(diags, r.diag(n, "setInterval handle is discarded",
+						"Without the handle the interval can never be cleared and runs for the life of the page or process. Keep it and call clearInterval when the owner is done."))
+				case !clearedSomewhere(ctx.Root, handle, []string{"clearInterval", "clearTimeout"}, ctx.Source):
+					diags = append(diags, r.diag(n, fmt.Sprintf("Interval %s is never cleared", handle),
+						fmt.Sprintf("Nothing calls clearInterval(%s), so the callback keeps firing after its owner is gone. Clear it on shutdown/unmount.", handle)))
+				}
+			case "setTimeout":
+				if reschedulesSelf(n, positionalArgument(n, 0), ctx.Source) && !clearedSomewhere(ctx.Root, handle, []string{"clearTimeout"}, ctx.Source) {
+					diags = append(diags, r.diag(n, "setTimeout reschedules itself with no stop condition",
+						"The timeout re-arms on every run, which is an interval that can never be stopped. Check a stop flag before rescheduling or keep the handle and clearTimeout it."))
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r AsyncLeakedTimer) diag(call *sitter.Node, message, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     message,
+		Explanation: explanation,
+		Range:       rangeFromNode(call),
+	}
+}
+
+// checktask flags tasks that are dropped or stored and never used.
+func (r AsyncLeakedTimer) checkTask(call *sitter.Node, name string, source []byte) (diagnostic.Diagnostic, bool) {
+	if handleEscapes(call) {
+		return diagnostic.Diagnostic{}, false
+	}
+	handle := timerHandle(call, source)
+	if handle == "" {
+		return r.diag(call, fmt.Sprintf("%s() result is discarded", name),
+			"The event loop keeps only a weak reference to tasks, so an unreferenced task can be garbage-collected mid-run and its exceptions are never seen. Store it, await it, or add it to a set with a done callback."), true
+	}
+	if strings.Contains(handle, ".") {
+		return diagnostic.Diagnostic{}, false
+	}
+	scope := enclosingFunction(call)
+	if scope == nil {
+		scope = rootOf(call)
+	}
+	used := false
+	walkScope(scope, func(n *sitter.Node) bool {
+		if n.Type() == "identifier" && n.StartByte() >= call.EndByte() && content(source, n) == handle {
+			used = true
+		}
+		return !used
+	})
+	if used {
+		return diagnostic.Diagnostic{}, false
+	}
+	return r.diag(call, fmt.Sprintf("Task %s is never awaited or cancelled", handle),
+		fmt.Sprintf("%s goes out of scope without being awaited, cancelled or stored, so failures are lost and the task can be collected mid-run.", handle)), true
+}
+
+// timerhandle returns the variable or attribute a timer is assigned to.
+func timerHandle(call *sitter.Node, source []byte) string {
+	expr := call
+	if p := expr.Parent(); p != nil && (p.Type() == "await" || p.Type() == "await_expression") {
+		expr = p
+	}
+	parent := expr.Parent()
+	if parent == nil {
+		return ""
+	}
+	switch parent.Type() {
+	case "assignment", "assignment_expression":
+		return content(source, parent.ChildByFieldName("left"))
+	case "variable_declarator":
+		return content(source, parent.ChildByFieldName("name"))
+	}
+	return ""
+}
+
+// handleescapes reports timers returned or passed on to other code.
+func handleEscapes(call *sitter.Node) bool {
+	parent := call.Parent()
+	if parent != nil && (parent.Type() == "await" || parent.Type() == "await_expression") {
+		return true
+	}
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "return_statement", "arrow_function", "argument_list", "arguments", "array", "list", "pair", "yield", "yield_expression":
+		return true
+	}
+	return false
+}
+
+// clearedsomewhere looks for clear(handle) or handle.cancel() in the file.
+func clearedSomewhere(root *sitter.Node, handle string, clearers []string, source []byte) bool {
+	if handle == "" {
+		return false
+	}
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || found {
+			return
+		}
+		if n.Type() == "call" || n.Type() == "call_expression" {
+			callee := calleeName(n, source)
+			for _, c := range clearers {
+				if callee == c && (strings.HasPrefix(c, handle+".") || passesName(n, handle, source)) {
+					found = true
+					return
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return found
+}
+
+// reschedulesself reports timers whose callback re-enters the enclosing
+// function on every path.
+func reschedulesSelf(call, callback *sitter.Node, source []byte) bool {
+	fn := enclosingFunction(call)
+	if fn == nil || callback == nil {
+		return false
+	}
+	name := functionName(fn, source)
+	if name == "" {
+		return false
+	}
+	target := content(source, callback)
+	if idx := strings.LastIndex(target, "."); idx != -1 {
+		// self._tick / this.tick
+		target = target[idx+1:]
+	}
+	if target != name && !(isInlineFunction(callback) && callsName(callback, name, source)) {
+		return false
+	}
+	for p := call.Parent(); p != nil && !p.Equal(fn); p = p.Parent() {
+		switch p.Type() {
+		case "if_statement", "conditional_expression", "ternary_expression", "while_statement", "try_statement", "boolean_operator":
+			return false
+		case "binary_expression":
+			if op := p.ChildByFieldName("operator"); op != nil && (content(source, op) == "&&" || content(source, op) == "||") {
+				return false
+			}
+		}
+	}
+	return !hasEarlyExit(fn)
+}
+
+func callsName(n *sitter.Node, name string, source []byte) bool {
+	found := false
+	walkScope(n.ChildByFieldName("body"), func(c *sitter.Node) bool {
+		if (c.Type() == "call" || c.Type() == "call_expression") && strings.HasSuffix(calleeName(c, source), name) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// hasearlyexit matches a guarded return/throw that can stop the loop.
+func hasEarlyExit(fn *sitter.Node) bool {
+	found := false
+	walkScope(fn.ChildByFieldName("body"), func(n *sitter.Node) bool {
+		if n.Type() == "if_statement" {
+			walkScope(n, func(c *sitter.Node) bool {
+				switch c.Type() {
+				case "return_statement", "throw_statement", "raise_statement":
+					found = true
+				}
+				return !found
+			})
+		}
+		return !found
+	})
+	return found
+}
+
+func isTaskGroup(name string) bool {
+	receiver := strings.ToLower(strings.TrimSuffix(name, ".create_task"))
+	return receiver == "tg" || strings.Contains(receiver, "group") || strings.Contains(receiver, "nursery")
+}
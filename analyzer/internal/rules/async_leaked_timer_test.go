@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestAsyncLeakedTimerPython(t *testing.T) {
+	src := []byte(`
+import asyncio
+import threading
+
+def heartbeat():
+    send_ping()
+    threading.Timer(30, heartbeat).start()
+
+def poll(self):
+    if self.stopped:
+        return
+    threading.Timer(5, self.poll).start()
+
+async def handler(event):
+    asyncio.create_task(audit(event))
+    task = asyncio.create_task(notify(event))
+    kept = asyncio.create_task(refresh())
+    await kept
+    async with asyncio.TaskGroup() as tg:
+        tg.create_task(work())
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewAsyncLeakedTimer()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestAsyncLeakedTimerJS(t *testing.T) {
+	src := []byte(`
+setInterval(flush, 1000);
+const poller = setInterval(poll, 5000);
+
+function useClock(setNow) {
+  const id = setInterval(() => setNow(Date.now()), 1000);
+  return () => clearInterval(id);
+}
+
+function tick() {
+  render();
+  setTimeout(tick, 16);
+}
+
+function retryLater(attempt) {
+  if (attempt > 5) return;
+  setTimeout(() => retryLater(attempt + 1), 1000);
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewAsyncLeakedTimer()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
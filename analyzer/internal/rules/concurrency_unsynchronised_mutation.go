@@ -0,0 +1,319 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ConcurrencyUnsynchronisedMutation struct{}
+
+// newconcurrencyunsynchronisedmutation builds rule.
+func NewConcurrencyUnsynchronisedMutation() Rule { return ConcurrencyUnsynchronisedMutation{} }
+
+func (ConcurrencyUnsynchronisedMutation) ID() string { return "concurrency.unsynchronised_mutation" }
+
+func (ConcurrencyUnsynchronisedMutation) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"concurrency", "state"},
+		Short:           "Shared state mutated without a lock",
+		Long:            "Module-level state written from threads, executor tasks or request handlers without a lock races.",
+	}
+}
+
+func (ConcurrencyUnsynchronisedMutation) Supports(language string) bool {
+	return strings.EqualFold(language, "python")
+}
+
+func (r ConcurrencyUnsynchronisedMutation) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r ConcurrencyUnsynchronisedMutation) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	decls := map[string]binding{}
+	uses := map[string]*collectionUse{}
+	for _, b := range pythonModuleBindings(ctx.Root, ctx.Source) {
+		if _, ok := decls[b.name]; !ok {
+			decls[b.name] = b
+			uses[b.name] = &collectionUse{guarded: !isMutableValue(b.value, ctx.Source, imports)}
+		}
+	}
+	if len(decls) == 0 {
+		return nil
+	}
+	functions := map[string][]*sitter.Node{}
+	var ordered []*sitter.Node
+	walkFunctions(ctx.Root, func(fn *sitter.Node) {
+		name := content(ctx.Source, fn.ChildByFieldName("name"))
+		functions[name] = append(functions[name], fn)
+		ordered = append(ordered, fn)
+	})
+	contexts := concurrentContexts(ctx, functions, imports)
+
+	var diags []diagnostic.Diagnostic
+	for _, fn := range ordered {
+		name := content(ctx.Source, fn.ChildByFieldName("name"))
+		reason, ok := contexts[name]
+		if !ok {
+			continue
+		}
+		for _, w := range pythonSharedWrites(fn, uses, ctx.Source) {
+			if guardedByLock(w.node, fn, ctx.Source) {
+				continue
+			}
+			diags = append(diags, diagnostic.Diagnostic{
+				RuleID:      r.ID(),
+				Message:     fmt.Sprintf("Module-level %s written from %s without a lock", w.name, name),
+				Explanation: fmt.Sprintf("%s runs %s, so concurrent calls can interleave this write with others and lose updates or corrupt the value. Guard it with a threading.Lock (asyncio.Lock for coroutines) or keep the state per request.", name, reason),
+				Range:       rangeFromNode(w.node),
+				Related:     []diagnostic.Related{{Message: "declared here", Range: rangeFromNode(decls[w.name].decl)}},
+			})
+		}
+	}
+	return diags
+}
+
+// sharedwrite is one write to a module-level name.
+type sharedWrite struct {
+	name string
+	node *sitter.Node
+}
+
+// pythonsharedwrites finds mutating calls and item writes on module-level
+// containers, and global rebinds of any module-level name, in fn.
+// uses marks immutable bindings as guarded so only rebinds count.
+func pythonSharedWrites(fn *sitter.Node, uses map[string]*collectionUse, source []byte) []sharedWrite {
+	var out []sharedWrite
+	track := func(name string, n *sitter.Node) {
+		if use := trackedUse(uses, fn, name, source); use != nil && !use.guarded {
+			out = append(out, sharedWrite{name: name, node: n})
+		}
+	}
+	rebind := func(name string, n *sitter.Node) {
+		if uses[name] != nil && declaresGlobal(fn, name, source) {
+			out = append(out, sharedWrite{name: name, node: n})
+		}
+	}
+	walkScope(fn.ChildByFieldName("body"), func(n *sitter.Node) bool {
+		switch n.Type() {
+		case "call":
+			callee := n.ChildByFieldName("function")
+			if callee != nil && callee.Type() == "attribute" {
+				method := content(source, callee.ChildByFieldName("attribute"))
+				if matchesAny(method, pyInsertMethods...) || matchesAny(method, pyEvictMethods...) || matchesAny(method, "sort", "reverse") {
+					track(content(source, callee.ChildByFieldName("object")), n)
+				}
+			}
+		case "assignment", "augmented_assignment":
+			left := n.ChildByFieldName("left")
+			switch {
+			case left == nil:
+			case left.Type() == "subscript":
+				track(content(source, left.ChildByFieldName("value")), n)
+			case left.Type() == "identifier":
+				rebind(content(source, left), n)
+			}
+		case "delete_statement":
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				if target := n.NamedChild(i); target != nil && target.Type() == "subscript" {
+					track(content(source, target.ChildByFieldName("value")), n)
+				}
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// concurrentcontexts maps function names to why they run concurrently:
+// thread/executor targets, request handlers and functions they call.
+func concurrentContexts(ctx Context, functions map[string][]*sitter.Node, imports map[string]string) map[string]string {
+	root, source := ctx.Root, ctx.Source
+	out := map[string]string{}
+	for name, fns := range functions {
+		for _, fn := range fns {
+			if h := ctx.EnclosingHandler(fn); h != nil {
+				out[name] = fmt.Sprintf("as part of a %s request handler", h.Framework)
+			}
+		}
+	}
+	mark := func(target *sitter.Node, reason string) {
+		if target == nil {
+			return
+		}
+		name := content(source, target)
+		if idx := strings.LastIndex(name, "."); idx != -1 {
+			// self.worker -> worker
+			name = name[idx+1:]
+		}
+		if _, ok := functions[name]; ok {
+			if _, seen := out[name]; !seen {
+				out[name] = reason
+			}
+		}
+	}
+	pools := poolBindings(root, imports, source)
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "call":
+			name := resolveImport(calleeName(n, source), imports)
+			last := name
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				last = name[idx+1:]
+			}
+			receiver := strings.TrimSuffix(name, "."+last)
+			switch {
+			case name == "threading.Thread":
+				mark(keywordArgumentValue(n, "target", source), "as a Thread target")
+			case name == "threading.Timer":
+				mark(positionalArgument(n, 1), "on a Timer thread")
+			case name == "asyncio.to_thread":
+				mark(positionalArgument(n, 0), "in a worker thread via asyncio.to_thread")
+			case last == "run_in_executor":
+				mark(positionalArgument(n, 1), "in an executor via run_in_executor")
+			case last == "submit" && pools[receiver] == "executor":
+				mark(positionalArgument(n, 0), "as an executor task")
+			case matchesAny(last, "map", "imap", "imap_unordered", "starmap", "apply_async") && pools[receiver] != "":
+				mark(positionalArgument(n, 0), "as a pool task")
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+
+	// helpers called from a concurrent function run concurrently too.
+	queue := make([]string, 0, len(out))
+	for name := range out {
+		queue = append(queue, name)
+	}
+	sort.Strings(queue)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, fn := range functions[name] {
+			walkScope(fn.ChildByFieldName("body"), func(n *sitter.Node) bool {
+				if n.Type() != "call" {
+					return true
+				}
+				callee := calleeName(n, source)
+				if idx := strings.LastIndex(callee, "."); idx != -1 {
+					if !strings.HasPrefix(callee, "self.") && !strings.HasPrefix(callee, "cls.") {
+						return true
+					}
+					callee = callee[idx+1:]
+				}
+				if _, ok := functions[callee]; ok {
+					if _, seen := out[callee]; !seen {
+						out[callee] = fmt.Sprintf("when called from %s", name)
+						queue = append(queue, callee)
+					}
+				}
+				return true
+			})
+		}
+	}
+	return out
+}
+
+// guardedbylock reports writes inside `with lock:` or after lock.acquire().
+func guardedByLock(n, fn *sitter.Node, source []byte) bool {
+	for p := n.Parent(); p != nil && !p.Equal(fn); p = p.Parent() {
+		if p.Type() != "with_statement" {
+			continue
+		}
+		for i := 0; i < int(p.NamedChildCount()); i++ {
+			if clause := p.NamedChild(i); clause.Type() == "with_clause" && mentionsLock(content(source, clause)) {
+				return true
+			}
+		}
+	}
+	// lock.acquire() earlier in fn with no lock.release() in between.
+	held := map[string]bool{}
+	walkScope(fn.ChildByFieldName("body"), func(c *sitter.Node) bool {
+		if c.StartByte() >= n.StartByte() {
+			return false
+		}
+		if c.Type() != "call" {
+			return true
+		}
+		name := calleeName(c, source)
+		receiver, method := name, ""
+		if idx := strings.LastIndex(name, "."); idx != -1 {
+			receiver, method = name[:idx], name[idx+1:]
+		}
+		switch {
+		case method == "acquire" && mentionsLock(receiver):
+			held[receiver] = true
+		case method == "release":
+			delete(held, receiver)
+		}
+		return true
+	})
+	return len(held) > 0
+}
+
+func mentionsLock(text string) bool {
+	text = strings.ToLower(text)
+	return strings.Contains(text, "lock") || strings.Contains(text, "mutex") || strings.Contains(text, "semaphore")
+}
+
+// poolbindings maps names bound to executor or process pool constructors,
+// via assignment or with ... as, to "executor" or "pool".
+func poolBindings(root *sitter.Node, imports map[string]string, source []byte) map[string]string {
+	out := map[string]string{}
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		var target, value *sitter.Node
+		switch n.Type() {
+		case "as_pattern":
+			target, value = n.ChildByFieldName("alias"), n.NamedChild(0)
+		case "assignment":
+			target, value = n.ChildByFieldName("left"), n.ChildByFieldName("right")
+		}
+		if target != nil && value != nil && value.Type() == "call" {
+			switch resolveImport(calleeName(value, source), imports) {
+			case "concurrent.futures.ThreadPoolExecutor", "concurrent.futures.ProcessPoolExecutor":
+				out[content(source, target)] = "executor"
+			case "multiprocessing.Pool", "multiprocessing.pool.Pool", "multiprocessing.pool.ThreadPool":
+				out[content(source, target)] = "pool"
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return out
+}
+
+// walkfunctions visits every function_definition, including methods.
+func walkFunctions(n *sitter.Node, visit func(*sitter.Node)) {
+	if n == nil {
+		return
+	}
+	if n.Type() == "function_definition" {
+		visit(n)
+	}
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		walkFunctions(n.NamedChild(i), visit)
+	}
+}
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestConcurrencyUnsynchronisedMutation(t *testing.T) {
+	src := []byte(`
+import threading
+from concurrent.futures import ThreadPoolExecutor
+
+CACHE = {}
+RESULTS = []
+COUNT = 0
+LIMIT = 5
+lock = threading.Lock()
+
+def record(key, value):
+    CACHE[key] = value
+
+def worker(item):
+    global COUNT
+    COUNT += 1
+    RESULTS.append(item)
+    record(item, LIMIT)
+
+def guarded(item):
+    with lock:
+        RESULTS.append(item)
+
+def local(item):
+    RESULTS = []
+    RESULTS.append(item)
+
+@app.post("/items")
+async def create(item):
+    del CACHE[item]
+
+def startup():
+    RESULTS.clear()
+
+with ThreadPoolExecutor() as pool:
+    pool.submit(worker, 1)
+    pool.submit(guarded, 1)
+    pool.submit(local, 1)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewConcurrencyUnsynchronisedMutation()
+	handlers := FindHandlers("python", "worker.py", root, src)
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src, Handlers: handlers})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 4 {
+		t.Fatalf("expected 4 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestConcurrencyUnsynchronisedMutationAcquireRelease(t *testing.T) {
+	src := []byte(`
+import threading
+
+RESULTS = []
+lock = threading.Lock()
+
+def worker(item):
+    lock.acquire()
+    try:
+        RESULTS.append(item)
+    finally:
+        lock.release()
+    RESULTS.append(None)
+
+threading.Thread(target=worker, args=(1,)).start()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewConcurrencyUnsynchronisedMutation()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Range.Start.Line != 12 {
+		t.Fatalf("expected only the write after release(), got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestConcurrencyUnsynchronisedMutationExecutorReceivers(t *testing.T) {
+	src := []byte(`
+import concurrent.futures
+from multiprocessing import Pool
+
+SEEN = []
+
+def on_submit(event):
+    SEEN.append(event)
+
+def crawl(url):
+    SEEN.append(url)
+
+def collect(url):
+    SEEN.append(url)
+
+def wire(form, urls):
+    form.submit(on_submit)
+    self.executor = concurrent.futures.ThreadPoolExecutor(max_workers=4)
+    self.executor.submit(crawl, urls[0])
+    with Pool(4) as workers:
+        workers.map(collect, urls)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	diags, err := NewConcurrencyUnsynchronisedMutation().Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected crawl and collect only, got %d: %+v", len(diags), diags)
+	}
+	for _, d := range diags {
+		if d.Range.Start.Line == 8 {
+			t.Fatalf("form.submit handler flagged: %+v", d)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ConcurrencyWorkerLifecycle struct{}
+
+// newconcurrencyworkerlifecycle builds rule.
+func NewConcurrencyWorkerLifecycle() Rule { return ConcurrencyWorkerLifecycle{} }
+
+func (ConcurrencyWorkerLifecycle) ID() string { return "concurrency.worker_lifecycle" }
+
+func (ConcurrencyWorkerLifecycle) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"concurrency", "reliability"},
+		Short:           "Worker without lifecycle handling",
+		Long:            "Threads, pools and workers that are never joined, shut down or watched block clean shutdown and hide crashes.",
+	}
+}
+
+func (ConcurrencyWorkerLifecycle) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r ConcurrencyWorkerLifecycle) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// lifecycle lists what a worker needs before its owner goes away; each
+// step is satisfied by any one of its calls or events.
+type lifecycle struct {
+	kind   string
+	steps  [][]string
+	advice string
+}
+
+const (
+	joinAdvice     = "Join it (or set daemon=True for background work) so shutdown does not hang on it or lose its exceptions. Missing: %s."
+	shutdownAdvice = "Use a with block or call %s when done; idle executor threads keep the process alive and pending work is dropped."
+	poolAdvice     = "Use a with block or call %s so worker processes are reaped instead of lingering after the owner returns."
+)
+
+var pythonWorkerLifecycles = map[string]lifecycle{
+	"threading.Thread":                       {"Thread", [][]string{{"join"}}, joinAdvice},
+	"multiprocessing.Process":                {"Process", [][]string{{"join"}}, joinAdvice},
+	"concurrent.futures.ThreadPoolExecutor":  {"ThreadPoolExecutor", [][]string{{"shutdown"}}, shutdownAdvice},
+	"concurrent.futures.ProcessPoolExecutor": {"ProcessPoolExecutor", [][]string{{"shutdown"}}, shutdownAdvice},
+	"multiprocessing.Pool":                   {"Pool", [][]string{{"close", "terminate"}, {"join"}}, poolAdvice},
+	"multiprocessing.pool.Pool":              {"Pool", [][]string{{"close", "terminate"}, {"join"}}, poolAdvice},
+	"multiprocessing.pool.ThreadPool":        {"ThreadPool", [][]string{{"close", "terminate"}, {"join"}}, poolAdvice},
+}
+
+var jsWorkerLifecycle = lifecycle{"Worker", [][]string{{"error"}, {"exit"}}, "Listen for %s; an unhandled worker error is rethrown in the parent and a silent exit leaves work undone."}
+
+func (r ConcurrencyWorkerLifecycle) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			// daemon threads do not block interpreter exit.
+			daemon := keywordArgumentValue(n, "daemon", ctx.Source)
+			if lc, ok := pythonWorkerLifecycles[name]; ok && !insideWithItem(n) && (daemon == nil || content(ctx.Source, daemon) != "True") {
+				if missing := r.missingSteps(n, lc, ctx.Source, pythonStepDone); len(missing) > 0 {
+					diags = append(diags, r.diag(n, lc, missing))
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r ConcurrencyWorkerLifecycle) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "new_expression" {
+			name := jsModuleCallee(strings.TrimSpace(content(ctx.Source, n.ChildByFieldName("constructor"))), imports)
+			if matchesAny(name, "Worker", "worker_threads.Worker") {
+				if missing := r.missingSteps(n, jsWorkerLifecycle, ctx.Source, jsEventHandled); len(missing) > 0 {
+					diags = append(diags, r.diag(n, jsWorkerLifecycle, missing))
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r ConcurrencyWorkerLifecycle) diag(n *sitter.Node, lc lifecycle, missing []string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     fmt.Sprintf("%s created without %s", lc.kind, strings.Join(missing, " and ")),
+		Explanation: fmt.Sprintf(lc.advice, strings.Join(missing, " and ")),
+		Range:       rangeFromNode(n),
+	}
+}
+
+// missingsteps returns the lifecycle steps never performed on the handle.
+func (r ConcurrencyWorkerLifecycle) missingSteps(n *sitter.Node, lc lifecycle, source []byte, done func(scope *sitter.Node, handle, step string, source []byte) bool) []string {
+	parent := n.Parent()
+	handle := ""
+	if parent != nil {
+		switch parent.Type() {
+		case "return_statement", "arrow_function", "yield", "pair", "list", "array":
+			return nil
+		case "argument_list", "arguments":
+			// threads.append(Thread(...)) / stored for a later join.
+			if owner := parent.Parent(); owner != nil && isContainerInsert(calleeName(owner, source)) {
+				return nil
+			}
+		case "assignment", "assignment_expression":
+			handle = content(source, parent.ChildByFieldName("left"))
+		case "variable_declarator":
+			handle = content(source, parent.ChildByFieldName("name"))
+		}
+	}
+	scope := enclosingFunction(n)
+	if scope == nil || strings.Contains(handle, ".") {
+		// self.pool / this.worker are managed by other methods.
+		scope = rootOf(n)
+	}
+	if handle != "" && !strings.Contains(handle, ".") && handleStored(scope, n, handle, source) {
+		return nil
+	}
+	var missing []string
+	for _, step := range lc.steps {
+		ok := false
+		for _, alt := range step {
+			if handle != "" && done(scope, handle, alt, source) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			missing = append(missing, formatStep(step, lc.kind == "Worker"))
+		}
+	}
+	return missing
+}
+
+func formatStep(step []string, event bool) string {
+	parts := make([]string, len(step))
+	for i, s := range step {
+		if event {
+			parts[i] = fmt.Sprintf("'%s'", s)
+		} else {
+			parts[i] = s + "()"
+		}
+	}
+	return strings.Join(parts, " or ")
+}
+
+// handlestored reports handles returned or handed to a container.
+func handleStored(scope, created *sitter.Node, handle string, source []byte) bool {
+	stored := false
+	walkScope(scope, func(n *sitter.Node) bool {
+		if n.StartByte() < created.EndByte() {
+			return true
+		}
+		switch n.Type() {
+		case "return_statement":
+			stored = returnsName(n, handle, source)
+		case "call", "call_expression":
+			stored = passesName(n, handle, source) && isContainerInsert(calleeName(n, source))
+		case "assignment", "assignment_expression":
+			left := n.ChildByFieldName("left")
+			right := n.ChildByFieldName("right")
+			stored = left != nil && right != nil && left.Type() != "identifier" && content(source, right) == handle
+		}
+		return !stored
+	})
+	return stored
+}
+
+// pythonstepdone looks for handle.step(...) calls; daemon = True and
+// setDaemon(True) also stand in for join.
+func pythonStepDone(scope *sitter.Node, handle, step string, source []byte) bool {
+	done := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || done {
+			return
+		}
+		switch n.Type() {
+		case "call":
+			if fn := n.ChildByFieldName("function"); isAttributeOf(fn, handle, source) {
+				method := content(source, fn.ChildByFieldName("attribute"))
+				done = method == step || (step == "join" && method == "setDaemon" && content(source, positionalArgument(n, 0)) == "True")
+			}
+		case "assignment":
+			left := n.ChildByFieldName("left")
+			done = step == "join" && isAttributeOf(left, handle, source) &&
+				content(source, left.ChildByFieldName("attribute")) == "daemon" && content(source, n.ChildByFieldName("right")) == "True"
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(scope)
+	return done
+}
+
+// isattributeof matches handle.x where the object is exactly handle.
+func isAttributeOf(n *sitter.Node, handle string, source []byte) bool {
+	return n != nil && n.Type() == "attribute" && content(source, n.ChildByFieldName("object")) == handle
+}
+
+func jsEventHandled(scope *sitter.Node, handle, event string, source []byte) bool {
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || found {
+			return
+		}
+		switch n.Type() {
+		case "call_expression":
+			callee := calleeName(n, source)
+			if matchesAny(callee, handle+".on", handle+".once", handle+".addEventListener", handle+".addListener") {
+				if first := positionalArgument(n, 0); first != nil && stringLiteralValue(first, source) == event {
+					found = true
+					return
+				}
+			}
+		case "assignment_expression":
+			if content(source, n.ChildByFieldName("left")) == handle+".on"+event {
+				found = true
+				return
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(scope)
+	return found
+}
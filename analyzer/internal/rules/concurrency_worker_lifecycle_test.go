@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestConcurrencyWorkerLifecyclePython(t *testing.T) {
+	src := []byte(`
+import threading
+from concurrent.futures import ThreadPoolExecutor
+from multiprocessing import Pool
+
+def fire(job):
+    threading.Thread(target=job).start()
+
+def background(job):
+    threading.Thread(target=job, daemon=True).start()
+
+def joined(job):
+    t = threading.Thread(target=job)
+    t.start()
+    t.join()
+
+def fan_out(jobs):
+    pool = ThreadPoolExecutor(max_workers=4)
+    return [pool.submit(j) for j in jobs]
+
+def scoped(jobs):
+    with ThreadPoolExecutor() as pool:
+        return list(pool.map(run, jobs))
+
+def crunch(items):
+    pool = Pool(4)
+    out = pool.map(work, items)
+    pool.close()
+    return out
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewConcurrencyWorkerLifecycle()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestConcurrencyWorkerLifecyclePythonHandleMatch(t *testing.T) {
+	src := []byte(`
+import os
+import threading
+
+def build(a, b, job):
+    th = threading.Thread(target=job)
+    th.start()
+    return os.path.join(a, b)
+
+def daemonised(job):
+    th = threading.Thread(target=job)
+    th.daemon = True
+    th.start()
+
+def other_daemon(job, worker):
+    th = threading.Thread(target=job)
+    worker.th.daemon = True
+    th.start()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewConcurrencyWorkerLifecycle()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestConcurrencyWorkerLifecycleJS(t *testing.T) {
+	src := []byte(`
+const { Worker } = require("node:worker_threads");
+
+function start(file) {
+  const worker = new Worker(file);
+  worker.on("message", handle);
+}
+
+function watched(file) {
+  const worker = new Worker(file);
+  worker.on("error", report);
+  worker.once("exit", (code) => restart(code));
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewConcurrencyWorkerLifecycle()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(diags), diags)
+	}
+}
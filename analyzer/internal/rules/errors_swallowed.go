@@ -50,10 +50,7 @@ func (r ErrorsSwallowed) runPython(ctx Context) []diagnostic.Diagnostic {
 			return
 		}
 		if n.Type() == "except_clause" {
-			block := firstChildOfType(n, "block")
-			if block == nil {
-				block = firstChildOfType(n, "suite")
-			}
+			block := handlerBody(n)
 			if block == nil || isEmptyBlock(block) || isPassOnly(block) {
 				diags = append(diags, diagnostic.Diagnostic{
 					RuleID:      r.ID(),
@@ -79,10 +76,7 @@ func (r ErrorsSwallowed) runJS(ctx Context) []diagnostic.Diagnostic {
 			return
 		}
 		if n.Type() == "catch_clause" {
-			body := n.ChildByFieldName("body")
-			if body == nil {
-				body = firstChildOfType(n, "statement_block")
-			}
+			body := handlerBody(n)
 			if body == nil || body.NamedChildCount() == 0 || isEmptyBlock(body) {
 				diags = append(diags, diagnostic.Diagnostic{
 					RuleID:      r.ID(),
@@ -101,6 +95,57 @@ func (r ErrorsSwallowed) runJS(ctx Context) []diagnostic.Diagnostic {
 	return diags
 }
 
+// handlerbody returns the block of an except_clause or catch_clause.
+func handlerBody(clause *sitter.Node) *sitter.Node {
+	if clause.Type() == "catch_clause" {
+		if body := clause.ChildByFieldName("body"); body != nil {
+			return body
+		}
+		return firstChildOfType(clause, "statement_block")
+	}
+	if block := firstChildOfType(clause, "block"); block != nil {
+		return block
+	}
+	return firstChildOfType(clause, "suite")
+}
+
+// handlerclauses returns the except/catch clauses of a try statement.
+func handlerClauses(try *sitter.Node) []*sitter.Node {
+	var out []*sitter.Node
+	for i := 0; i < int(try.NamedChildCount()); i++ {
+		child := try.NamedChild(i)
+		if child != nil && (child.Type() == "except_clause" || child.Type() == "catch_clause") {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// excepttypes lists the names an except_clause matches; nil means bare except.
+func exceptTypes(clause *sitter.Node, source []byte) []string {
+	var out []string
+	var collect func(n *sitter.Node)
+	collect = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "identifier", "attribute":
+			out = append(out, content(source, n))
+		case "tuple", "parenthesized_expression":
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				collect(n.NamedChild(i))
+			}
+		case "as_pattern":
+			collect(n.NamedChild(0))
+		}
+	}
+	if clause.Type() == "except_clause" && clause.NamedChildCount() > 0 {
+		collect(clause.NamedChild(0))
+	}
+	return out
+}
+
 func isEmptyBlock(block *sitter.Node) bool {
 	return block != nil && block.NamedChildCount() == 0
 }
@@ -0,0 +1,186 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ErrorsUnguardedParse struct{}
+
+// newerrorsunguardedparse builds rule.
+func NewErrorsUnguardedParse() Rule { return ErrorsUnguardedParse{} }
+
+func (ErrorsUnguardedParse) ID() string { return "errors.unguarded_parse" }
+
+func (ErrorsUnguardedParse) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "errors"},
+		Short:           "Parse without error handling",
+		Long:            "Parsing external input outside a matching try turns one malformed payload into a crash or 500.",
+	}
+}
+
+func (ErrorsUnguardedParse) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r ErrorsUnguardedParse) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// pythonparseerrors maps parse calls to the exception names that cover them.
+var pythonParseErrors = map[string][]string{
+	"json.loads":     {"JSONDecodeError", "ValueError"},
+	"json.load":      {"JSONDecodeError", "ValueError"},
+	"response.json":  {"JSONDecodeError", "ValueError", "RequestException"},
+	"yaml.safe_load": {"YAMLError"},
+	"yaml.load":      {"YAMLError"},
+	"tomllib.loads":  {"TOMLDecodeError", "ValueError"},
+}
+
+func (r ErrorsUnguardedParse) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			written := calleeName(n, ctx.Source)
+			name := resolveImport(written, imports)
+			if isResponseJSON(name) && positionalArgument(n, 0) == nil {
+				// resp.json() on a requests/httpx response.
+				name = "response.json"
+			}
+			if accepted, ok := pythonParseErrors[name]; ok && !isConstantInput(n) && !insideMatchingExcept(n, accepted, ctx.Source) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     fmt.Sprintf("%s() without %s handling", written, accepted[0]),
+					Explanation: fmt.Sprintf("Malformed input raises %s here and nothing in this function catches it. Wrap the parse in try/except %s and return a clear error.", accepted[0], accepted[0]),
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r ErrorsUnguardedParse) runJS(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" && calleeName(n, ctx.Source) == "JSON.parse" {
+			arg := positionalArgument(n, 0)
+			// JSON.parse(JSON.stringify(x)) is a deep clone.
+			clone := arg != nil && arg.Type() == "call_expression" && calleeName(arg, ctx.Source) == "JSON.stringify"
+			if !clone && !isConstantInput(n) && !insideMatchingExcept(n, nil, ctx.Source) && !insideCaughtPromise(n, ctx.Source) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "JSON.parse outside try/catch",
+					Explanation: "JSON.parse throws a SyntaxError on malformed input, so one bad payload fails the whole request. Wrap it in try/catch and handle the invalid case.",
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// insidematchingexcept reports a try body around n, within the same
+// function, whose handlers catch one of accepted (or everything).
+func insideMatchingExcept(n *sitter.Node, accepted []string, source []byte) bool {
+	child := n
+	for p := n.Parent(); p != nil && !isFunctionNode(p); child, p = p, p.Parent() {
+		if p.Type() != "try_statement" {
+			continue
+		}
+		body := p.ChildByFieldName("body")
+		if body == nil || !body.Equal(child) {
+			continue
+		}
+		for _, clause := range handlerClauses(p) {
+			if clause.Type() == "catch_clause" {
+				return true
+			}
+			types := exceptTypes(clause, source)
+			if len(types) == 0 {
+				return true
+			}
+			for _, t := range types {
+				last := t
+				if idx := strings.LastIndex(t, "."); idx != -1 {
+					last = t[idx+1:]
+				}
+				if matchesAny(last, "Exception", "BaseException") || matchesAny(last, accepted...) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// insidecaughtpromise matches parses in a .then callback of a chain
+// that ends in .catch.
+func insideCaughtPromise(n *sitter.Node, source []byte) bool {
+	fn := enclosingFunction(n)
+	if fn == nil || fn.Parent() == nil || fn.Parent().Type() != "arguments" {
+		return false
+	}
+	call := fn.Parent().Parent()
+	if call == nil || !strings.HasSuffix(calleeName(call, source), ".then") {
+		return false
+	}
+	top := call
+	for p := top.Parent(); p != nil && (p.Type() == "member_expression" || p.Type() == "call_expression"); p = p.Parent() {
+		top = p
+	}
+	return strings.Contains(content(source, top)[call.EndByte()-top.StartByte():], ".catch(")
+}
+
+func isConstantInput(call *sitter.Node) bool {
+	arg := positionalArgument(call, 0)
+	return arg != nil && arg.Type() == "string" && firstChildOfType(arg, "interpolation") == nil
+}
+
+// isresponsejson matches resp.json() but not model.json() serialisers.
+func isResponseJSON(name string) bool {
+	receiver, ok := strings.CutSuffix(name, ".json")
+	return ok && isResponseReceiver(receiver)
+}
+
+// isresponsereceiver matches r, res, resp, reply and *response names.
+func isResponseReceiver(receiver string) bool {
+	if idx := strings.LastIndex(receiver, "."); idx != -1 {
+		receiver = receiver[idx+1:]
+	}
+	receiver = strings.ToLower(receiver)
+	return matchesAny(receiver, "r", "res", "resp", "reply") || strings.HasSuffix(receiver, "response") || strings.HasSuffix(receiver, "resp")
+}
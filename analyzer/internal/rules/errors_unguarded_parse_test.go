@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestErrorsUnguardedParsePython(t *testing.T) {
+	src := []byte(`
+import json
+from json import loads
+
+def bare(resp):
+    return json.loads(resp.text)
+
+def wrong_type(resp):
+    try:
+        return loads(resp.text)
+    except KeyError:
+        return None
+
+def guarded(resp):
+    try:
+        return json.loads(resp.text)
+    except json.JSONDecodeError:
+        return None
+
+def response(resp):
+    try:
+        return resp.json()
+    except (ValueError, TypeError):
+        return None
+
+DEFAULTS = json.loads("{}")
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewErrorsUnguardedParse()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestErrorsUnguardedParseJS(t *testing.T) {
+	src := []byte(`
+function handle(body) {
+  const data = JSON.parse(body);
+  try {
+    return JSON.parse(body);
+  } catch (err) {
+    return null;
+  }
+}
+const copy = JSON.parse(JSON.stringify(state));
+fetchText().then((text) => JSON.parse(text)).catch(report);
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewErrorsUnguardedParse()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(diags), diags)
+	}
+}
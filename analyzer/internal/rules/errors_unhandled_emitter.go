@@ -0,0 +1,199 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ErrorsUnhandledEmitter struct{}
+
+// newerrorsunhandledemitter builds rule.
+func NewErrorsUnhandledEmitter() Rule { return ErrorsUnhandledEmitter{} }
+
+func (ErrorsUnhandledEmitter) ID() string { return "errors.unhandled_emitter" }
+
+func (ErrorsUnhandledEmitter) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "errors"},
+		Short:           "Emitter without 'error' listener",
+		Long:            "Node throws 'error' events that have no listener, crashing the process.",
+	}
+}
+
+func (ErrorsUnhandledEmitter) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r ErrorsUnhandledEmitter) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "javascript", "typescript":
+		return r.run(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+var jsEmitterFactories = []string{
+	"fs.createReadStream", "fs.createWriteStream", "net.connect", "net.createConnection",
+	"tls.connect", "http.request", "http.get", "https.request", "https.get",
+	"child_process.spawn", "events.EventEmitter", "EventEmitter", "net.Socket",
+}
+
+func (r ErrorsUnhandledEmitter) run(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		var name string
+		switch n.Type() {
+		case "call_expression":
+			name = calleeName(n, ctx.Source)
+		case "new_expression":
+			name = strings.TrimSpace(content(ctx.Source, n.ChildByFieldName("constructor")))
+		}
+		if name != "" {
+			name = jsModuleCallee(name, imports)
+			if name == "events" {
+				// import EventEmitter from "events"
+				name = "events.EventEmitter"
+			}
+			if matchesAny(name, jsEmitterFactories...) && !emitterErrorsHandled(n, ctx.Source) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     fmt.Sprintf("%s has no 'error' listener", name),
+					Explanation: "An 'error' event with no listener is thrown, which crashes the process on the first failed read, refused connection or reset. Add .on('error', ...) or wrap streams in pipeline().",
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// emittererrorshandled follows the emitter through its scope.
+func emitterErrorsHandled(n *sitter.Node, source []byte) bool {
+	parent := n.Parent()
+	if parent == nil {
+		return false
+	}
+	var handle string
+	switch parent.Type() {
+	case "return_statement", "arrow_function", "export_statement", "pair", "array":
+		// the caller owns the emitter.
+		return true
+	case "arguments":
+		// pipeline(src, dst) and finished(s, cb) attach listeners; other
+		// calls such as src.pipe(dst) or console.log(s) do not.
+		owner := parent.Parent()
+		return owner != nil && forwardsErrors(calleeName(owner, source))
+	case "member_expression":
+		return chainHandlesErrors(n, source)
+	case "variable_declarator":
+		id := parent.ChildByFieldName("name")
+		if id == nil || id.Type() != "identifier" {
+			return true
+		}
+		handle = content(source, id)
+	case "assignment_expression":
+		left := parent.ChildByFieldName("left")
+		if left == nil || left.Type() != "identifier" {
+			// this.stream = ... is handled by the owning object.
+			return true
+		}
+		handle = content(source, left)
+	default:
+		return false
+	}
+	scope := enclosingFunction(n)
+	if scope == nil {
+		scope = rootOf(n)
+	}
+	handled := false
+	var walk func(c *sitter.Node)
+	walk = func(c *sitter.Node) {
+		if c == nil || handled {
+			return
+		}
+		switch c.Type() {
+		case "call_expression":
+			callee := calleeName(c, source)
+			if isErrorListener(c, callee, handle, source) || (passesName(c, handle, source) && forwardsErrors(callee)) {
+				handled = true
+				return
+			}
+			if strings.HasPrefix(callee, handle+".") && chainHandlesErrors(c, source) {
+				handled = true
+				return
+			}
+		case "return_statement":
+			if returnsName(c, handle, source) {
+				handled = true
+				return
+			}
+		case "assignment_expression", "pair", "shorthand_property_identifier":
+			// stored on an object or handed back in a literal.
+			if right := c.ChildByFieldName("right"); right != nil && content(source, right) == handle {
+				handled = true
+				return
+			}
+			if value := c.ChildByFieldName("value"); value != nil && content(source, value) == handle {
+				handled = true
+				return
+			}
+			if c.Type() == "shorthand_property_identifier" && content(source, c) == handle {
+				handled = true
+				return
+			}
+		}
+		for i := 0; i < int(c.NamedChildCount()); i++ {
+			walk(c.NamedChild(i))
+		}
+	}
+	walk(scope)
+	return handled
+}
+
+func isErrorListener(call *sitter.Node, callee, handle string, source []byte) bool {
+	for _, m := range []string{".on", ".once", ".addListener", ".prependListener"} {
+		if callee == handle+m {
+			event := positionalArgument(call, 0)
+			return event != nil && stringLiteralValue(event, source) == "error"
+		}
+	}
+	return false
+}
+
+// chainhandleserrors reports .on("error") somewhere in a call chain.
+func chainHandlesErrors(n *sitter.Node, source []byte) bool {
+	top := n
+	for p := top.Parent(); p != nil && (p.Type() == "member_expression" || p.Type() == "call_expression"); p = p.Parent() {
+		top = p
+	}
+	text := content(source, top)
+	return strings.Contains(text, `.on("error"`) || strings.Contains(text, `.on('error'`) || strings.Contains(text, ".on(`error`")
+}
+
+// forwardserrors matches stream helpers that listen for 'error' on the
+// streams passed to them.
+func forwardsErrors(callee string) bool {
+	last := callee
+	if idx := strings.LastIndex(callee, "."); idx != -1 {
+		last = callee[idx+1:]
+	}
+	return matchesAny(last, "pipeline", "finished", "pump", "eos") || matchesAny(callee, "once", "events.once")
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestErrorsUnhandledEmitter(t *testing.T) {
+	src := []byte(`
+const fs = require("node:fs");
+const https = require("https");
+const { pipeline } = require("stream");
+const { EventEmitter } = require("events");
+
+function serve(path, res) {
+  fs.createReadStream(path).pipe(res);
+}
+
+function download(url, dest) {
+  https.get(url, (res) => res.pipe(fs.createWriteStream(dest)));
+}
+
+function copy(src, dest, done) {
+  pipeline(fs.createReadStream(src), fs.createWriteStream(dest), done);
+}
+
+function listen(url) {
+  const req = https.request(url);
+  req.on("error", (err) => log(err));
+  req.end();
+  const bus = new EventEmitter();
+  bus.emit("ready");
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewErrorsUnhandledEmitter()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 4 {
+		t.Fatalf("expected 4 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestErrorsUnhandledEmitterLoggedHandle(t *testing.T) {
+	src := []byte(`
+const fs = require("node:fs");
+const { finished } = require("stream");
+
+function serve(path, res) {
+  const s = fs.createReadStream(path);
+  console.log(s);
+  s.pipe(res);
+}
+
+function tail(path, done) {
+  const s = fs.createReadStream(path);
+  finished(s, done);
+  s.resume();
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	diags, err := NewErrorsUnhandledEmitter().Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Range.Start.Line != 5 {
+		t.Fatalf("expected only the logged stream, got %+v", diags)
+	}
+}
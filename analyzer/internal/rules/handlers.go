@@ -0,0 +1,318 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// handler is a function a web framework calls per request.
+type Handler struct {
+	Name      string
+	Framework string
+	Node      *sitter.Node
+	Range     diagnostic.Range
+}
+
+// enclosinghandler returns the request handler containing n, or nil.
+func (c Context) EnclosingHandler(n *sitter.Node) *Handler {
+	for p := n; p != nil; p = p.Parent() {
+		if !isFunctionNode(p) {
+			continue
+		}
+		for i := range c.Handlers {
+			if c.Handlers[i].Node.Equal(p) {
+				return &c.Handlers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// findhandlers lists route handlers: decorated views, django views,
+// app.get('/x', fn) registrations and next.js route exports. path gates
+// django views and next.js exports, which are only recognised by file.
+func FindHandlers(language, path string, root *sitter.Node, source []byte) []Handler {
+	switch strings.ToLower(language) {
+	case "python":
+		return pythonHandlers(path, root, source)
+	case "javascript", "typescript":
+		return jsHandlers(path, root, source)
+	default:
+		return nil
+	}
+}
+
+var jsRouteMethods = []string{"get", "post", "put", "patch", "delete", "del", "all", "head", "options"}
+
+// nextroutemethods are the exports of an app router route.ts.
+var nextRouteMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// pythonappfactories build objects whose methods register routes.
+var pythonAppFactories = []string{
+	"flask.Flask", "flask.Blueprint", "fastapi.FastAPI", "fastapi.APIRouter",
+	"starlette.applications.Starlette", "starlette.routing.Router", "sanic.Sanic", "sanic.Blueprint",
+}
+
+var pythonRouteMethods = []string{"get", "post", "put", "patch", "delete", "head", "options", "route", "api_route", "websocket"}
+
+func pythonHandlers(path string, root *sitter.Node, source []byte) []Handler {
+	imports := pythonImports(root, source)
+	framework := "http"
+	for _, f := range []string{"fastapi", "flask", "django", "rest_framework", "starlette", "sanic"} {
+		if importsModule(imports, f) {
+			framework = f
+			break
+		}
+	}
+	apps := pythonAppBindings(root, source, imports)
+	django := importsModule(imports, "django", "rest_framework")
+	routed := djangoRoutedViews(root, source)
+	viewsModule := isDjangoViewsPath(path)
+	var out []Handler
+	add := func(fn *sitter.Node) {
+		out = append(out, Handler{
+			Name:      content(source, fn.ChildByFieldName("name")),
+			Framework: framework,
+			Node:      fn,
+			Range:     rangeFromNode(fn),
+		})
+	}
+	walkFunctions(root, func(fn *sitter.Node) {
+		name := content(source, fn.ChildByFieldName("name"))
+		if p := fn.Parent(); p != nil && p.Type() == "decorated_definition" {
+			for i := 0; i < int(p.NamedChildCount()); i++ {
+				dec := p.NamedChild(i)
+				if dec.Type() == "decorator" && (isRouteDecorator(dec, apps, source) || resolveImport(decoratorName(dec, source), imports) == "rest_framework.decorators.api_view") {
+					add(fn)
+					return
+				}
+			}
+		}
+		if !django || !(viewsModule || routed[name]) {
+			return
+		}
+		// def view(request, ...) and View.get(self, request, ...).
+		_, params, _ := functionParts(fn, source)
+		if len(params) > 0 && params[0] == "self" {
+			params = params[1:]
+		}
+		if len(params) > 0 && params[0] == "request" {
+			add(fn)
+		}
+	})
+	return out
+}
+
+// pythonappbindings names module-level flask/fastapi/starlette apps and
+// routers: app = Flask(__name__), router = APIRouter().
+func pythonAppBindings(root *sitter.Node, source []byte, imports map[string]string) map[string]bool {
+	out := map[string]bool{}
+	walkScope(root, func(n *sitter.Node) bool {
+		if n.Type() != "assignment" {
+			return true
+		}
+		left, right := n.ChildByFieldName("left"), n.ChildByFieldName("right")
+		if left != nil && right != nil && right.Type() == "call" && matchesAny(resolveImport(calleeName(right, source), imports), pythonAppFactories...) {
+			out[content(source, left)] = true
+		}
+		return true
+	})
+	return out
+}
+
+// isroutedecorator matches @app.get(...), @router.post(...), @bp.route(...)
+// on a known app or router, not @mock.patch(...) or @cache.get(...).
+func isRouteDecorator(dec *sitter.Node, apps map[string]bool, source []byte) bool {
+	expr := dec.NamedChild(0)
+	if expr != nil && expr.Type() == "call" {
+		expr = expr.ChildByFieldName("function")
+	}
+	if expr == nil || expr.Type() != "attribute" {
+		return false
+	}
+	receiver := content(source, expr.ChildByFieldName("object"))
+	if !apps[receiver] && !matchesAny(receiver, "app", "router", "bp", "blueprint") {
+		return false
+	}
+	return matchesAny(content(source, expr.ChildByFieldName("attribute")), pythonRouteMethods...)
+}
+
+// decoratorname returns api_view for @api_view(["GET"]).
+func decoratorName(dec *sitter.Node, source []byte) string {
+	expr := dec.NamedChild(0)
+	if expr != nil && expr.Type() == "call" {
+		return calleeName(expr, source)
+	}
+	return content(source, expr)
+}
+
+// djangoroutedviews names functions passed to path()/re_path()/url() in
+// this file, for views registered next to their urlpatterns.
+func djangoRoutedViews(root *sitter.Node, source []byte) map[string]bool {
+	out := map[string]bool{}
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" && matchesAny(calleeName(n, source), "path", "re_path", "url", "django.urls.path", "django.urls.re_path") {
+			if view := positionalArgument(n, 1); view != nil && view.Type() == "identifier" {
+				out[content(source, view)] = true
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return out
+}
+
+// isdjangoviewspath matches views.py and modules under a views package.
+func isDjangoViewsPath(path string) bool {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	if parts[len(parts)-1] == "views.py" {
+		return true
+	}
+	for _, dir := range parts[:len(parts)-1] {
+		if dir == "views" {
+			return true
+		}
+	}
+	return false
+}
+
+// isnextroutepath matches app/**/route.ts; isnextapipath matches
+// pages/api/**.
+func isNextRoutePath(path string) bool {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	base := parts[len(parts)-1]
+	if !strings.HasPrefix(base, "route.") {
+		return false
+	}
+	for _, dir := range parts[:len(parts)-1] {
+		if dir == "app" {
+			return true
+		}
+	}
+	return false
+}
+
+func isNextAPIPath(path string) bool {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for i := 0; i+1 < len(parts)-1; i++ {
+		if parts[i] == "pages" && parts[i+1] == "api" {
+			return true
+		}
+	}
+	return false
+}
+
+func jsHandlers(path string, root *sitter.Node, source []byte) []Handler {
+	imports := jsImports(root, source)
+	framework := "http"
+	for _, f := range []string{"express", "koa", "@koa/router", "koa-router", "fastify", "hono", "next"} {
+		if importsModule(imports, f) {
+			framework = strings.TrimPrefix(strings.TrimSuffix(f, "-router"), "@")
+			framework = strings.TrimSuffix(framework, "/router")
+			break
+		}
+	}
+	declared := map[string]*sitter.Node{}
+	var out []Handler
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "function_declaration":
+			declared[content(source, n.ChildByFieldName("name"))] = n
+		case "variable_declarator":
+			if value := n.ChildByFieldName("value"); isInlineFunction(value) {
+				declared[content(source, n.ChildByFieldName("name"))] = value
+			}
+		case "export_statement":
+			// next.js: export async function GET(req) / export default function handler(req, res).
+			decl := n.ChildByFieldName("declaration")
+			switch {
+			case decl == nil:
+			case decl.Type() == "function_declaration":
+				name := content(source, decl.ChildByFieldName("name"))
+				_, params, _ := functionParts(decl, source)
+				isDefault := strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(content(source, n), "export")), "default")
+				if (isNextRoutePath(path) && isNextRouteMethod(name)) || (isNextAPIPath(path) && isDefault && len(params) == 2 && params[0] == "req" && params[1] == "res") {
+					out = append(out, Handler{Name: name, Framework: "next", Node: decl, Range: rangeFromNode(decl)})
+				}
+			case decl.Type() == "lexical_declaration" || decl.Type() == "variable_declaration":
+				// export const GET = async (req) => {...}
+				for i := 0; i < int(decl.NamedChildCount()); i++ {
+					d := decl.NamedChild(i)
+					if d == nil || d.Type() != "variable_declarator" {
+						continue
+					}
+					name := content(source, d.ChildByFieldName("name"))
+					if value := d.ChildByFieldName("value"); isInlineFunction(value) && isNextRoutePath(path) && isNextRouteMethod(name) {
+						out = append(out, Handler{Name: name, Framework: "next", Node: value, Range: rangeFromNode(value)})
+					}
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+
+	// app.get("/x", auth, handler): every function after the path runs per request.
+	var register func(n *sitter.Node)
+	register = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := calleeName(n, source)
+			method := name[strings.LastIndex(name, ".")+1:]
+			route := positionalArgument(n, 0)
+			if strings.Contains(name, ".") && matchesAny(method, jsRouteMethods...) && isRoutePath(route, source) {
+				args := n.ChildByFieldName("arguments")
+				for i := 1; args != nil && i < int(args.NamedChildCount()); i++ {
+					fn := args.NamedChild(i)
+					if fn != nil && fn.Type() == "identifier" {
+						fn = declared[content(source, fn)]
+					}
+					if fn != nil && (isInlineFunction(fn) || fn.Type() == "function_declaration") {
+						out = append(out, Handler{
+							Name:      fmt.Sprintf("%s %s", strings.ToUpper(method), strings.Trim(content(source, route), "'\"`")),
+							Framework: framework,
+							Node:      fn,
+							Range:     rangeFromNode(fn),
+						})
+					}
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			register(n.NamedChild(i))
+		}
+	}
+	register(root)
+	return out
+}
+
+func isNextRouteMethod(name string) bool {
+	return matchesAny(name, nextRouteMethods...) && name == strings.ToUpper(name)
+}
+
+// isroutepath matches "/users/:id" and "*" route patterns.
+func isRoutePath(n *sitter.Node, source []byte) bool {
+	if n == nil || (n.Type() != "string" && n.Type() != "template_string") {
+		return false
+	}
+	text := strings.Trim(content(source, n), "'\"`")
+	return strings.HasPrefix(text, "/") || text == "*"
+}
@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestFindHandlersJS(t *testing.T) {
+	src := []byte(`
+import express from "express";
+
+export async function POST(req) {
+  return Response.json({});
+}
+
+export const GET = async (req) => Response.json(await load());
+
+export const config = { runtime: "edge" };
+
+const app = express();
+app.get("/users/:id", auth, (req, res) => res.send("ok"));
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	handlers := FindHandlers("javascript", "app/api/users/route.ts", root, src)
+	assertHandlerNames(t, handlers, "POST", "GET", "GET /users/:id")
+
+	// route exports only count in app/**/route.ts.
+	assertHandlerNames(t, FindHandlers("javascript", "lib/users.ts", root, src), "GET /users/:id")
+}
+
+func TestFindHandlersPython(t *testing.T) {
+	src := []byte(`
+from unittest import mock
+from flask import Blueprint
+from fastapi import APIRouter
+from rest_framework.decorators import api_view
+
+users = Blueprint("users", __name__)
+api = APIRouter()
+
+@users.route("/users")
+def list_users():
+    return []
+
+@api.post("/items")
+async def create_item(item):
+    return item
+
+@api_view(["GET"])
+def health(request):
+    return Response()
+
+@mock.patch("app.client")
+def test_client(client):
+    pass
+
+@cache.get("key")
+def cached():
+    pass
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	assertHandlerNames(t, FindHandlers("python", "api.py", root, src), "list_users", "create_item", "health")
+}
+
+func TestFindHandlersDjangoViews(t *testing.T) {
+	src := []byte(`
+from django.http import JsonResponse
+from django.views import View
+
+def index(request):
+    return JsonResponse({})
+
+class Orders(View):
+    def get(self, request, pk):
+        return JsonResponse({})
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	assertHandlerNames(t, FindHandlers("python", "shop/views.py", root, src), "index", "get")
+	// the same helpers outside a views module are not handlers.
+	assertHandlerNames(t, FindHandlers("python", "shop/middleware.py", root, src))
+}
+
+func assertHandlerNames(t *testing.T, handlers []Handler, want ...string) {
+	t.Helper()
+	var names []string
+	for _, h := range handlers {
+		names = append(names, h.Name)
+	}
+	if len(names) != len(want) {
+		t.Fatalf("expected handlers %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected handlers %v, got %v", want, names)
+		}
+	}
+}
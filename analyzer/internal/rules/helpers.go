@@ -30,3 +30,82 @@ func matchesAny(s string, options ...string) bool {
 	}
 	return false
 }
+
+// keywordargumentvalue returns the value of name=... in a python call.
+func keywordArgumentValue(call *sitter.Node, name string, source []byte) *sitter.Node {
+	if call == nil {
+		return nil
+	}
+	args := call.ChildByFieldName("arguments")
+	if args == nil {
+		return nil
+	}
+	for i := 0; i < int(args.NamedChildCount()); i++ {
+		arg := args.NamedChild(i)
+		if arg == nil || arg.Type() != "keyword_argument" {
+			continue
+		}
+		if strings.TrimSpace(content(source, arg.ChildByFieldName("name"))) == name {
+			return arg.ChildByFieldName("value")
+		}
+	}
+	return nil
+}
+
+// positionalargument returns the idx-th non-keyword argument of a call.
+func positionalArgument(call *sitter.Node, idx int) *sitter.Node {
+	if call == nil {
+		return nil
+	}
+	args := call.ChildByFieldName("arguments")
+	if args == nil {
+		return nil
+	}
+	pos := 0
+	for i := 0; i < int(args.NamedChildCount()); i++ {
+		arg := args.NamedChild(i)
+		if arg == nil || arg.Type() == "keyword_argument" || arg.Type() == "comment" {
+			continue
+		}
+		if pos == idx {
+			return arg
+		}
+		pos++
+	}
+	return nil
+}
+
+// objectpropertyvalue returns the value for key in a js object literal.
+// shorthand properties return the identifier itself.
+func objectPropertyValue(obj *sitter.Node, key string, source []byte) *sitter.Node {
+	if obj == nil || obj.Type() != "object" {
+		return nil
+	}
+	for i := 0; i < int(obj.NamedChildCount()); i++ {
+		prop := obj.NamedChild(i)
+		if prop == nil {
+			continue
+		}
+		switch prop.Type() {
+		case "pair":
+			k := prop.ChildByFieldName("key")
+			name := strings.Trim(content(source, k), "'\"`")
+			if name == key {
+				return prop.ChildByFieldName("value")
+			}
+		case "shorthand_property_identifier":
+			if content(source, prop) == key {
+				return prop
+			}
+		}
+	}
+	return nil
+}
+
+// calleename returns trimmed callee text for call/call_expression nodes.
+func calleeName(call *sitter.Node, source []byte) string {
+	if call == nil {
+		return ""
+	}
+	return strings.TrimSpace(content(source, call.ChildByFieldName("function")))
+}
@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// pythonimports maps local names to qualified import paths.
+func pythonImports(root *sitter.Node, source []byte) map[string]string {
+	out := map[string]string{}
+	if root == nil {
+		return out
+	}
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "import_statement":
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				child := n.NamedChild(i)
+				if child == nil {
+					continue
+				}
+				switch child.Type() {
+				case "dotted_name":
+					full := content(source, child)
+					head := strings.SplitN(full, ".", 2)[0]
+					out[head] = head
+				case "aliased_import":
+					name := content(source, child.ChildByFieldName("name"))
+					alias := content(source, child.ChildByFieldName("alias"))
+					if alias != "" {
+						out[alias] = name
+					}
+				}
+			}
+			return
+		case "import_from_statement":
+			module := content(source, n.ChildByFieldName("module_name"))
+			if module == "" || strings.HasPrefix(module, ".") {
+				return
+			}
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				child := n.NamedChild(i)
+				if child == nil || child.Equal(n.ChildByFieldName("module_name")) {
+					continue
+				}
+				switch child.Type() {
+				case "dotted_name":
+					name := content(source, child)
+					out[name] = module + "." + name
+				case "aliased_import":
+					name := content(source, child.ChildByFieldName("name"))
+					alias := content(source, child.ChildByFieldName("alias"))
+					if alias != "" {
+						out[alias] = module + "." + name
+					}
+				}
+			}
+			return
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return out
+}
+
+// jsimports maps local names to module specifiers.
+// named imports resolve to "module.name".
+func jsImports(root *sitter.Node, source []byte) map[string]string {
+	out := map[string]string{}
+	if root == nil {
+		return out
+	}
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "import_statement":
+			module := stringLiteralValue(n.ChildByFieldName("source"), source)
+			clause := firstChildOfType(n, "import_clause")
+			if module == "" || clause == nil {
+				return
+			}
+			for i := 0; i < int(clause.NamedChildCount()); i++ {
+				child := clause.NamedChild(i)
+				if child == nil {
+					continue
+				}
+				switch child.Type() {
+				case "identifier":
+					out[content(source, child)] = module
+				case "namespace_import":
+					if id := firstChildOfType(child, "identifier"); id != nil {
+						out[content(source, id)] = module
+					}
+				case "named_imports":
+					for j := 0; j < int(child.NamedChildCount()); j++ {
+						spec := child.NamedChild(j)
+						if spec == nil || spec.Type() != "import_specifier" {
+							continue
+						}
+						name := content(source, spec.ChildByFieldName("name"))
+						local := name
+						if alias := spec.ChildByFieldName("alias"); alias != nil {
+							local = content(source, alias)
+						}
+						out[local] = module + "." + name
+					}
+				}
+			}
+			return
+		case "variable_declarator":
+			module := requireSpecifier(n.ChildByFieldName("value"), source)
+			name := n.ChildByFieldName("name")
+			if module != "" && name != nil {
+				switch name.Type() {
+				case "identifier":
+					out[content(source, name)] = module
+				case "object_pattern":
+					for j := 0; j < int(name.NamedChildCount()); j++ {
+						prop := name.NamedChild(j)
+						if prop == nil {
+							continue
+						}
+						switch prop.Type() {
+						case "shorthand_property_identifier_pattern":
+							local := content(source, prop)
+							out[local] = module + "." + local
+						case "pair_pattern":
+							key := content(source, prop.ChildByFieldName("key"))
+							local := content(source, prop.ChildByFieldName("value"))
+							out[local] = module + "." + key
+						}
+					}
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return out
+}
+
+// resolveimport swaps the leading name segment for its import path.
+func resolveImport(name string, imports map[string]string) string {
+	name = strings.TrimSpace(name)
+	head, rest, dotted := strings.Cut(name, ".")
+	full, ok := imports[head]
+	if !ok {
+		return name
+	}
+	if dotted {
+		return full + "." + rest
+	}
+	return full
+}
+
+// requirespecifier returns the module of require("x").
+func requireSpecifier(n *sitter.Node, source []byte) string {
+	if n == nil || n.Type() != "call_expression" {
+		return ""
+	}
+	fn := n.ChildByFieldName("function")
+	if fn == nil || content(source, fn) != "require" {
+		return ""
+	}
+	args := n.ChildByFieldName("arguments")
+	if args == nil || args.NamedChildCount() == 0 {
+		return ""
+	}
+	return stringLiteralValue(args.NamedChild(0), source)
+}
+
+// stringliteralvalue returns the unquoted text of a plain string literal.
+func stringLiteralValue(n *sitter.Node, source []byte) string {
+	if n == nil || n.Type() != "string" {
+		return ""
+	}
+	text := content(source, n)
+	return strings.Trim(text, "'\"`")
+}
@@ -0,0 +1,296 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type NetCallInLoop struct{}
+
+// newnetcallinloop builds rule.
+func NewNetCallInLoop() Rule { return NetCallInLoop{} }
+
+func (NetCallInLoop) ID() string { return "net.call_in_loop" }
+
+func (NetCallInLoop) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"performance", "network"},
+		Short:           "Network call inside loop",
+		Long:            "Per-item network and database calls are slow and multiply load on dependencies during incidents.",
+	}
+}
+
+func (NetCallInLoop) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r NetCallInLoop) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r NetCallInLoop) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node, inLoop bool)
+	walk = func(n *sitter.Node, inLoop bool) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "function_definition", "lambda":
+			inLoop = false
+		case "call":
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			if name == "asyncio.gather" && isSplattedComprehension(n) && !mentionsConcurrencyLimit(n, ctx.Source, imports) {
+				diags = append(diags, r.fanOutDiag(n, "asyncio.gather(*[...]) starts every coroutine at once; bound it with an asyncio.Semaphore or process the items in chunks."))
+				// the comprehension is the fan-out, not a loop of calls.
+				return
+			}
+			if inLoop {
+				if d, ok := r.loopDiag(n, name, isRequestsFunction(name)); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			walk(child, inLoop || isRepeatedChild(n, child, ctx.Source))
+		}
+	}
+	walk(ctx.Root, false)
+	return diags
+}
+
+func (r NetCallInLoop) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node, inLoop bool)
+	walk = func(n *sitter.Node, inLoop bool) {
+		if n == nil {
+			return
+		}
+		if isFunctionNode(n) {
+			// callbacks of forEach/map run once per item.
+			inLoop = isIterationCallback(n, ctx.Source)
+		}
+		if n.Type() == "call_expression" {
+			name := calleeName(n, ctx.Source)
+			if matchesAny(name, "Promise.all", "Promise.allSettled") {
+				if mapped := positionalArgument(n, 0); isUnboundedMap(mapped, ctx.Source) && !mentionsConcurrencyLimit(n, ctx.Source, imports) {
+					diags = append(diags, r.fanOutDiag(n, "Promise.all(items.map(...)) fires every request at once; cap concurrency with p-limit/p-map or process the items in batches."))
+					return
+				}
+			}
+			if inLoop {
+				if d, ok := r.loopDiag(n, name, isJSHTTPFunction(name)); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			walk(child, inLoop || isRepeatedChild(n, child, ctx.Source))
+		}
+	}
+	walk(ctx.Root, false)
+	return diags
+}
+
+func (r NetCallInLoop) loopDiag(call *sitter.Node, name string, isHTTP bool) (diagnostic.Diagnostic, bool) {
+	switch {
+	case isHTTP:
+		return diagnostic.Diagnostic{
+			RuleID:      r.ID(),
+			Message:     fmt.Sprintf("%s called inside a loop", name),
+			Explanation: "One request per item is slow and multiplies traffic when a dependency is struggling; use a batch endpoint or bounded concurrency.",
+			Range:       rangeFromNode(call),
+		}, true
+	case isDBCall(name):
+		return diagnostic.Diagnostic{
+			RuleID:      r.ID(),
+			Message:     fmt.Sprintf("Query %s inside a loop (N+1)", name),
+			Explanation: "A query per row turns one page load into N round trips; fetch the rows in one query (IN (...), JOIN, select_related/prefetch_related).",
+			Range:       rangeFromNode(call),
+		}, true
+	}
+	return diagnostic.Diagnostic{}, false
+}
+
+func (r NetCallInLoop) fanOutDiag(call *sitter.Node, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Unbounded fan-out",
+		Explanation: explanation,
+		Range:       rangeFromNode(call),
+	}
+}
+
+// isdbcall matches common query calls: cursor.execute, session.query,
+// Django managers, pool.query and ORM finders.
+func isDBCall(name string) bool {
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return false
+	}
+	if strings.Contains(name, ".objects.") {
+		return true
+	}
+	last := name[idx+1:]
+	if matchesAny(last, "execute", "executemany", "query", "raw", "fetchrow", "fetchval") {
+		return true
+	}
+	// arrays have find/findIndex/findLast; ORMs have findOne, findById, ...
+	return strings.HasPrefix(last, "find") && !matchesAny(last, "find", "findIndex", "findLast", "findLastIndex")
+}
+
+// isrepeatedchild reports if child runs once per iteration of parent.
+// retry and polling loops repeat one call rather than one per item.
+func isRepeatedChild(parent, child *sitter.Node, source []byte) bool {
+	if child == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "for_statement", "for_in_statement", "while_statement", "do_statement":
+		if isRetryLoop(parent, source) {
+			return false
+		}
+		body := loopBody(parent)
+		return body != nil && body.Equal(child)
+	case "list_comprehension", "set_comprehension", "dictionary_comprehension", "generator_expression":
+		body := parent.ChildByFieldName("body")
+		return body != nil && body.Equal(child)
+	}
+	return false
+}
+
+func isIterationCallback(fn *sitter.Node, source []byte) bool {
+	args := fn.Parent()
+	if args == nil || args.Type() != "arguments" {
+		return false
+	}
+	call := args.Parent()
+	if call == nil {
+		return false
+	}
+	name := calleeName(call, source)
+	for _, m := range []string{".forEach", ".map", ".flatMap"} {
+		if strings.HasSuffix(name, m) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSplattedComprehension(call *sitter.Node) bool {
+	args := call.ChildByFieldName("arguments")
+	for i := 0; args != nil && i < int(args.NamedChildCount()); i++ {
+		arg := args.NamedChild(i)
+		if arg == nil || arg.Type() != "list_splat" {
+			continue
+		}
+		inner := arg.NamedChild(0)
+		if inner == nil {
+			continue
+		}
+		switch inner.Type() {
+		case "list_comprehension", "generator_expression", "parenthesized_expression", "call":
+			return true
+		}
+	}
+	return false
+}
+
+// isunboundedmap matches items.map(fetch) or items.map(async x => ...).
+func isUnboundedMap(n *sitter.Node, source []byte) bool {
+	if n == nil || n.Type() != "call_expression" || !strings.HasSuffix(calleeName(n, source), ".map") {
+		return false
+	}
+	cb := positionalArgument(n, 0)
+	if cb == nil {
+		return false
+	}
+	if cb.Type() == "identifier" || cb.Type() == "member_expression" {
+		return true
+	}
+	return isInlineFunction(cb) && strings.HasPrefix(strings.TrimSpace(content(source, cb)), "async")
+}
+
+// pythonlimiters and jslimiters construct concurrency limiters.
+var (
+	pythonLimiters = []string{"asyncio.Semaphore", "asyncio.BoundedSemaphore", "threading.Semaphore", "threading.BoundedSemaphore", "anyio.CapacityLimiter", "trio.CapacityLimiter"}
+	jsLimiters     = []string{"p-limit", "p-limit.default", "p-queue", "p-queue.default"}
+)
+
+// mentionsconcurrencylimit reports a semaphore or p-limit limiter created in
+// the fan-out's scope, or one bound at module level and used there, or a
+// p-map call with a concurrency option.
+func mentionsConcurrencyLimit(n *sitter.Node, source []byte, imports map[string]string) bool {
+	scope := enclosingFunction(n)
+	if scope == nil {
+		scope = rootOf(n)
+	}
+	inScope := func(c *sitter.Node) bool {
+		return c.StartByte() >= scope.StartByte() && c.EndByte() <= scope.EndByte()
+	}
+	limiters := map[string]bool{}
+	found := false
+	var walk func(c *sitter.Node)
+	walk = func(c *sitter.Node) {
+		if c == nil || found {
+			return
+		}
+		if c.Type() == "call" || c.Type() == "call_expression" || c.Type() == "new_expression" {
+			name := resolveImport(calleeName(c, source), imports)
+			if c.Type() == "new_expression" {
+				name = resolveImport(content(source, c.ChildByFieldName("constructor")), imports)
+			}
+			switch {
+			case matchesAny(name, pythonLimiters...) || matchesAny(name, jsLimiters...):
+				if inScope(c) {
+					found = true
+					return
+				}
+				if p := c.Parent(); p != nil && (p.Type() == "assignment" || p.Type() == "variable_declarator") {
+					left := p.ChildByFieldName("left")
+					if left == nil {
+						left = p.ChildByFieldName("name")
+					}
+					limiters[content(source, left)] = true
+				}
+			case matchesAny(name, "p-map", "p-map.default") && inScope(c):
+				if objectPropertyValue(positionalArgument(c, 2), "concurrency", source) != nil {
+					found = true
+					return
+				}
+			}
+		}
+		for i := 0; i < int(c.NamedChildCount()); i++ {
+			walk(c.NamedChild(i))
+		}
+	}
+	walk(rootOf(n))
+	if found {
+		return true
+	}
+	for _, id := range identifiersIn(scope, source) {
+		if limiters[id] {
+			return true
+		}
+	}
+	return false
+}
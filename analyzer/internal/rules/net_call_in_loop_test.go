@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestNetCallInLoopPython(t *testing.T) {
+	src := []byte(`
+import asyncio
+import requests
+
+def sync_users(ids):
+    for user_id in ids:
+        requests.get(f"/users/{user_id}", timeout=5)
+        User.objects.get(id=user_id)
+
+def names(ids):
+    return [requests.get(u, timeout=5) for u in ids]
+
+async def fan_out(ids):
+    return await asyncio.gather(*[fetch(i) for i in ids])
+
+async def bounded(ids):
+    sem = asyncio.Semaphore(10)
+    return await asyncio.gather(*[fetch(sem, i) for i in ids])
+
+def once():
+    return requests.get("/all", timeout=5)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetCallInLoop()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 4 {
+		t.Fatalf("expected 4 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestNetCallInLoopJS(t *testing.T) {
+	src := []byte(`
+import pLimit from "p-limit";
+
+async function load(ids) {
+  for (const id of ids) {
+    await fetch("/items/" + id);
+  }
+  ids.forEach(async (id) => { await axios.get("/x/" + id); });
+  return Promise.all(ids.map(async (id) => fetch("/y/" + id)));
+}
+
+async function limited(ids) {
+  const limit = pLimit(5);
+  return Promise.all(ids.map((id) => limit(() => fetch("/z/" + id))));
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetCallInLoop()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestNetCallInLoopSkipsRetriesAndNeedsRealLimiter(t *testing.T) {
+	src := []byte(`
+async function fetchWithRetry(url) {
+  for (let attempt = 0; attempt < 3; attempt++) {
+    try {
+      return await fetch(url);
+    } catch (e) {
+      continue;
+    }
+  }
+}
+
+async function page(ids) {
+  const rows = await db.query("select * from t limit(10)");
+  return Promise.all(ids.map(async (id) => fetch("/y/" + id)));
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetCallInLoop()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Message != "Unbounded fan-out" {
+		t.Fatalf("expected only the fan-out, got %d: %+v", len(diags), diags)
+	}
+}
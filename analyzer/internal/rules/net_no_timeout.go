@@ -81,7 +81,7 @@ func (r NetNoTimeout) runJS(ctx Context) []diagnostic.Diagnostic {
 		if n.Type() == "call_expression" {
 			fn := n.ChildByFieldName("function")
 			name := strings.TrimSpace(content(ctx.Source, fn))
-			if name == "fetch" && !hasFetchTimeout(n, ctx.Source) {
+			if isFetchCall(name) && !hasFetchTimeout(n, ctx.Source) {
 				diags = append(diags, diagnostic.Diagnostic{
 					RuleID:      r.ID(),
 					Severity:    "info",
@@ -91,7 +91,7 @@ func (r NetNoTimeout) runJS(ctx Context) []diagnostic.Diagnostic {
 					Tags:        []string{"reliability", "network"},
 				})
 			}
-			if strings.HasPrefix(name, "axios") && !argumentContains(n, "timeout", ctx.Source) {
+			if isAxiosCall(name) && !argumentContains(n, "timeout", ctx.Source) {
 				diags = append(diags, diagnostic.Diagnostic{
 					RuleID:      r.ID(),
 					Severity:    "info",
@@ -120,6 +120,19 @@ func isRequestsFunction(name string) bool {
 	return false
 }
 
+func isFetchCall(name string) bool {
+	return name == "fetch"
+}
+
+func isAxiosCall(name string) bool {
+	return strings.HasPrefix(name, "axios")
+}
+
+// isjshttpfunction matches the js clients net.no_timeout checks.
+func isJSHTTPFunction(name string) bool {
+	return isFetchCall(name) || isAxiosCall(name)
+}
+
 func hasKeywordArgument(call *sitter.Node, name string, source []byte) bool {
 	if call == nil {
 		return false
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type NetTLSUnverified struct{}
+
+// newnettlsunverified builds rule.
+func NewNetTLSUnverified() Rule { return NetTLSUnverified{} }
+
+func (NetTLSUnverified) ID() string { return "net.tls_unverified" }
+
+func (NetTLSUnverified) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "error",
+		Tags:            []string{"security", "network"},
+		Short:           "TLS certificate verification disabled",
+		Long:            "Disabling certificate checks lets anyone on the path read and alter traffic.",
+	}
+}
+
+func (NetTLSUnverified) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r NetTLSUnverified) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+const tlsExplanation = "Without certificate verification any proxy or attacker on the network can impersonate the server. Fix the trust store (custom CA bundle, NODE_EXTRA_CA_CERTS) instead of turning checks off."
+
+func (r NetTLSUnverified) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "call":
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			switch {
+			case name == "ssl._create_unverified_context":
+				diags = append(diags, r.diag(n, "Unverified SSL context"))
+			case isRequestsFunction(name) || isHTTPVerb(name) || strings.HasSuffix(name, "Session") || strings.HasSuffix(name, "Client"):
+				if v := keywordArgumentValue(n, "verify", ctx.Source); v != nil && content(ctx.Source, v) == "False" {
+					diags = append(diags, r.diag(v, fmt.Sprintf("%s called with verify=False", name)))
+				}
+				// aiohttp: session.get(url, ssl=False)
+				if v := keywordArgumentValue(n, "ssl", ctx.Source); v != nil && content(ctx.Source, v) == "False" {
+					diags = append(diags, r.diag(v, fmt.Sprintf("%s called with ssl=False", name)))
+				}
+			}
+		case "assignment":
+			left := n.ChildByFieldName("left")
+			right := n.ChildByFieldName("right")
+			if left == nil || right == nil || left.Type() != "attribute" {
+				break
+			}
+			attr := content(ctx.Source, left.ChildByFieldName("attribute"))
+			value := resolveImport(content(ctx.Source, right), imports)
+			switch {
+			case matchesAny(attr, "verify", "check_hostname") && value == "False":
+				diags = append(diags, r.diag(n, fmt.Sprintf("%s set to False", attr)))
+			case attr == "verify_mode" && value == "ssl.CERT_NONE":
+				diags = append(diags, r.diag(n, "verify_mode set to CERT_NONE"))
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r NetTLSUnverified) runJS(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "object":
+			// https.Agent, tls.connect, axios and request options.
+			for _, key := range []string{"rejectUnauthorized", "strictSSL"} {
+				if v := objectPropertyValue(n, key, ctx.Source); v != nil && content(ctx.Source, v) == "false" {
+					diags = append(diags, r.diag(v.Parent(), fmt.Sprintf("%s: false disables certificate checks", key)))
+				}
+			}
+		case "assignment_expression":
+			left := n.ChildByFieldName("left")
+			right := n.ChildByFieldName("right")
+			if left == nil || right == nil || !strings.Contains(content(ctx.Source, left), "NODE_TLS_REJECT_UNAUTHORIZED") {
+				break
+			}
+			if v := strings.Trim(content(ctx.Source, right), "'\"`"); v == "0" {
+				diags = append(diags, r.diag(n, "NODE_TLS_REJECT_UNAUTHORIZED=0 disables certificate checks process-wide"))
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r NetTLSUnverified) diag(n *sitter.Node, message string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     message,
+		Explanation: tlsExplanation,
+		Range:       rangeFromNode(n),
+	}
+}
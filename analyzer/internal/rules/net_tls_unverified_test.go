@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestNetTLSUnverifiedPython(t *testing.T) {
+	src := []byte(`
+import ssl
+import httpx
+import requests
+
+requests.get(url, verify=False, timeout=5)
+requests.get(url, verify="/etc/ca.pem", timeout=5)
+client = httpx.Client(verify=False)
+ctx = ssl._create_unverified_context()
+strict = ssl.create_default_context()
+strict.check_hostname = False
+strict.verify_mode = ssl.CERT_NONE
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetTLSUnverified()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 5 {
+		t.Fatalf("expected 5 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestNetTLSUnverifiedJS(t *testing.T) {
+	src := []byte(`
+const agent = new https.Agent({ rejectUnauthorized: false });
+const safe = new https.Agent({ rejectUnauthorized: true });
+process.env.NODE_TLS_REJECT_UNAUTHORIZED = "0";
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetTLSUnverified()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
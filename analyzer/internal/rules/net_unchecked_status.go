@@ -0,0 +1,234 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type NetUncheckedStatus struct{}
+
+// newnetuncheckedstatus builds rule.
+func NewNetUncheckedStatus() Rule { return NetUncheckedStatus{} }
+
+func (NetUncheckedStatus) ID() string { return "net.unchecked_status" }
+
+func (NetUncheckedStatus) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "network"},
+		Short:           "Response used without status check",
+		Long:            "Reading a response body without checking the status treats error pages as data.",
+	}
+}
+
+func (NetUncheckedStatus) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r NetUncheckedStatus) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+var (
+	pyStatusChecks = []string{"raise_for_status", "status_code", "ok", "is_success", "is_error"}
+	pyBodyReads    = []string{"json", "text", "content", "iter_content", "iter_lines"}
+	jsStatusChecks = []string{"ok", "status"}
+	jsBodyReads    = []string{"json", "text", "blob", "arrayBuffer", "formData", "body"}
+)
+
+func (r NetUncheckedStatus) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			if isRequestsFunction(name) && isHTTPVerb(name) {
+				if d, ok := r.check(n, name, ctx.Source, pyStatusChecks, pyBodyReads); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r NetUncheckedStatus) runJS(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		// axios rejects non-2xx by default; only fetch resolves on 500.
+		if n.Type() == "call_expression" && calleeName(n, ctx.Source) == "fetch" {
+			if d, ok := r.check(n, "fetch", ctx.Source, jsStatusChecks, jsBodyReads); ok {
+				diags = append(diags, d)
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// check follows the response of one request to its first body read.
+func (r NetUncheckedStatus) check(call *sitter.Node, name string, source []byte, checks, reads []string) (diagnostic.Diagnostic, bool) {
+	expr := call
+	for p := expr.Parent(); p != nil && (p.Type() == "await_expression" || p.Type() == "await" || p.Type() == "parenthesized_expression"); p = expr.Parent() {
+		expr = p
+	}
+	parent := expr.Parent()
+	if parent == nil {
+		return diagnostic.Diagnostic{}, false
+	}
+	var handle string
+	scope := enclosingFunction(call)
+	if scope == nil {
+		scope = rootOf(call)
+	}
+	switch parent.Type() {
+	case "attribute", "member_expression":
+		// requests.get(url).json() / (await fetch(url)).json()
+		prop := parent.ChildByFieldName("attribute")
+		if prop == nil {
+			prop = parent.ChildByFieldName("property")
+		}
+		method := content(source, prop)
+		if matchesAny(method, reads...) {
+			return r.uncheckedDiag(parent, call, name), true
+		}
+		if method != "then" {
+			return diagnostic.Diagnostic{}, false
+		}
+		// fetch(url).then((res) => res.json())
+		owner := parent.Parent()
+		if owner == nil || owner.Type() != "call_expression" {
+			return diagnostic.Diagnostic{}, false
+		}
+		cb := positionalArgument(owner, 0)
+		if cb == nil || !isInlineFunction(cb) {
+			return diagnostic.Diagnostic{}, false
+		}
+		_, params, body := functionParts(cb, source)
+		if len(params) == 0 || body == nil {
+			return diagnostic.Diagnostic{}, false
+		}
+		handle, scope = params[0], body
+	case "assignment", "assignment_expression":
+		left := parent.ChildByFieldName("left")
+		if left == nil || left.Type() != "identifier" {
+			return diagnostic.Diagnostic{}, false
+		}
+		handle = content(source, left)
+	case "variable_declarator":
+		id := parent.ChildByFieldName("name")
+		if id == nil || id.Type() != "identifier" {
+			return diagnostic.Diagnostic{}, false
+		}
+		handle = content(source, id)
+	default:
+		return diagnostic.Diagnostic{}, false
+	}
+	read := firstUncheckedRead(scope, expr.EndByte(), handle, source, checks, reads)
+	if read == nil {
+		return diagnostic.Diagnostic{}, false
+	}
+	return r.uncheckedDiag(read, call, name), true
+}
+
+func (r NetUncheckedStatus) uncheckedDiag(read, call *sitter.Node, name string) diagnostic.Diagnostic {
+	fix := "check res.ok (or res.status) and throw before reading the body"
+	if call.Type() == "call" {
+		fix = "call raise_for_status() or check status_code before reading the body"
+	}
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Response body read without checking status",
+		Explanation: fmt.Sprintf("%s() returns error responses normally, so a 500 page or error JSON is parsed as data; %s.", name, fix),
+		Range:       rangeFromNode(read),
+		Related:     []diagnostic.Related{{Message: "request made here", Range: rangeFromNode(call)}},
+	}
+}
+
+// firstuncheckedread returns the first body read of handle after start
+// that is not preceded by a status check or a hand-off to a helper.
+func firstUncheckedRead(scope *sitter.Node, start uint32, handle string, source []byte, checks, reads []string) *sitter.Node {
+	var checked, read *sitter.Node
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.StartByte() >= start {
+			switch n.Type() {
+			case "attribute", "member_expression":
+				obj := n.ChildByFieldName("object")
+				prop := n.ChildByFieldName("attribute")
+				if prop == nil {
+					prop = n.ChildByFieldName("property")
+				}
+				if obj != nil && content(source, obj) == handle {
+					method := content(source, prop)
+					if matchesAny(method, checks...) {
+						checked = earlier(checked, n)
+					} else if matchesAny(method, reads...) {
+						read = earlier(read, n)
+					}
+				}
+			case "call", "call_expression":
+				// ensure_ok(res) delegates the check.
+				if passesName(n, handle, source) {
+					checked = earlier(checked, n)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(scope)
+	if read == nil || (checked != nil && checked.StartByte() < read.StartByte()) {
+		return nil
+	}
+	return read
+}
+
+func earlier(current, n *sitter.Node) *sitter.Node {
+	if current == nil || n.StartByte() < current.StartByte() {
+		return n
+	}
+	return current
+}
+
+// ishttpverb matches requests.get(...) style calls, not Session or Client.
+func isHTTPVerb(name string) bool {
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return false
+	}
+	return matchesAny(name[idx+1:], "get", "post", "put", "patch", "delete", "head", "options", "request")
+}
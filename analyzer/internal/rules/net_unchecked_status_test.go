@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestNetUncheckedStatusPython(t *testing.T) {
+	src := []byte(`
+import requests
+
+def inline(url):
+    return requests.get(url, timeout=5).json()
+
+def assigned(url):
+    res = requests.get(url, timeout=5)
+    return res.json()
+
+def checked(url):
+    res = requests.get(url, timeout=5)
+    res.raise_for_status()
+    return res.json()
+
+def status_code(url):
+    res = requests.post(url, timeout=5)
+    if res.status_code != 200:
+        raise RuntimeError(res.text)
+    return res.json()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetUncheckedStatus()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestNetUncheckedStatusJS(t *testing.T) {
+	src := []byte(`
+async function a(url) {
+  const res = await fetch(url);
+  return res.json();
+}
+async function b(url) {
+  const res = await fetch(url);
+  if (!res.ok) throw new Error(res.statusText);
+  return res.json();
+}
+function c(url) {
+  return fetch(url).then((res) => res.json());
+}
+async function d(url) {
+  const res = await fetch(url);
+  await ensureOk(res);
+  return res.json();
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewNetUncheckedStatus()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
@@ -0,0 +1,357 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type QueueConsumerSafety struct{}
+
+// newqueueconsumersafety builds rule.
+func NewQueueConsumerSafety() Rule { return QueueConsumerSafety{} }
+
+func (QueueConsumerSafety) ID() string { return "queue.consumer_safety" }
+
+func (QueueConsumerSafety) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "queues"},
+		Short:           "Message consumer can lose messages",
+		Long:            "Auto-commit/auto-ack, missing dead-letter queues and unguarded consumer loops drop or stall messages during failures.",
+	}
+}
+
+func (QueueConsumerSafety) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r QueueConsumerSafety) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// pythonpollmethods fetch the next message in a consumer loop.
+var pythonPollMethods = []string{"poll", "consume", "receive_message", "basic_get"}
+
+func (r QueueConsumerSafety) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	if !importsModule(imports, "kafka", "confluent_kafka", "pika", "boto3") {
+		return nil
+	}
+	consumes := callsMethod(ctx.Root, "basic_consume", ctx.Source)
+	// names bound to KafkaConsumer(...) are iterated directly.
+	consumers := map[string]bool{}
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "call":
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			last := name
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				last = name[idx+1:]
+			}
+			switch {
+			case matchesAny(name, "kafka.KafkaConsumer", "kafka.consumer.KafkaConsumer"):
+				if p := n.Parent(); p != nil && p.Type() == "assignment" {
+					consumers[content(ctx.Source, p.ChildByFieldName("left"))] = true
+				}
+				if v := keywordArgumentValue(n, "enable_auto_commit", ctx.Source); v == nil || content(ctx.Source, v) != "False" {
+					diags = append(diags, r.autoCommitDiag(n, "KafkaConsumer", "enable_auto_commit=False"))
+				}
+			case name == "confluent_kafka.Consumer":
+				conf := pythonConfigDict(positionalArgument(n, 0), ctx.Source)
+				// auto commit of manually stored offsets is the at-least-once setup.
+				if conf != nil && !isFalseValue(pythonDictValue(conf, "enable.auto.commit", ctx.Source), ctx.Source) &&
+					!isFalseValue(pythonDictValue(conf, "enable.auto.offset.store", ctx.Source), ctx.Source) {
+					diags = append(diags, r.autoCommitDiag(n, "Consumer", "'enable.auto.commit': False"))
+				}
+			case matchesAny(last, "basic_consume", "basic_get"):
+				ack := keywordArgumentValue(n, "auto_ack", ctx.Source)
+				if ack == nil {
+					ack = keywordArgumentValue(n, "no_ack", ctx.Source)
+				}
+				if ack != nil && content(ctx.Source, ack) == "True" {
+					diags = append(diags, diagnostic.Diagnostic{
+						RuleID:      r.ID(),
+						Message:     fmt.Sprintf("%s with auto_ack=True", last),
+						Explanation: "The broker forgets each message as soon as it is delivered, so a crash or exception in the callback loses it. Leave auto_ack off and basic_ack after processing (basic_nack on failure).",
+						Range:       rangeFromNode(n),
+					})
+				}
+				if last == "basic_consume" {
+					cb := keywordArgumentValue(n, "on_message_callback", ctx.Source)
+					if cb == nil {
+						cb = positionalArgument(n, 1)
+					}
+					diags = append(diags, r.pythonCallbackDiags(cb, ctx)...)
+				}
+			case last == "queue_declare" && consumes:
+				if !isTemporaryQueue(n, ctx.Source) && !hasDeadLetter(keywordArgumentValue(n, "arguments", ctx.Source), ctx.Source) {
+					diags = append(diags, r.deadLetterDiag(n, "Queue declared without a dead-letter exchange", "Messages rejected with requeue=False or expired are dropped, and requeueing a poison message loops forever. Pass arguments={'x-dead-letter-exchange': ...} so failures are parked for inspection."))
+				}
+			case last == "create_queue" && keywordArgumentValue(n, "QueueName", ctx.Source) != nil:
+				attrs := keywordArgumentValue(n, "Attributes", ctx.Source)
+				if attrs == nil || (attrs.Type() == "dictionary" && pythonDictValue(attrs, "RedrivePolicy", ctx.Source) == nil) {
+					diags = append(diags, r.deadLetterDiag(n, "SQS queue created without a RedrivePolicy", "Without a dead-letter queue a message that keeps failing is retried until its retention expires and is then deleted. Set Attributes={'RedrivePolicy': ...} with a maxReceiveCount."))
+				}
+			}
+		case "while_statement":
+			if isInfiniteLoop(n, ctx.Source) && pollsInLoop(n, pythonPollMethods, ctx.Source) && !hasTryStatement(loopBody(n)) {
+				diags = append(diags, r.loopDiag(n))
+			}
+		case "for_statement":
+			if consumers[content(ctx.Source, n.ChildByFieldName("right"))] && !hasTryStatement(loopBody(n)) {
+				diags = append(diags, r.loopDiag(n))
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r QueueConsumerSafety) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	kafka := importsModule(imports, "kafkajs")
+	amqp := importsModule(imports, "amqplib")
+	if !kafka && !amqp {
+		return nil
+	}
+	consumes := callsMethod(ctx.Root, "consume", ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := calleeName(n, ctx.Source)
+			last := name
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				last = name[idx+1:]
+			}
+			switch {
+			case amqp && last == "consume":
+				if ack := objectPropertyValue(positionalArgument(n, 2), "noAck", ctx.Source); ack != nil && content(ctx.Source, ack) == "true" {
+					diags = append(diags, diagnostic.Diagnostic{
+						RuleID:      r.ID(),
+						Message:     "consume with noAck: true",
+						Explanation: "The broker forgets each message as soon as it is delivered, so a crash or exception in the handler loses it. Drop noAck and channel.ack(msg) after processing (nack on failure).",
+						Range:       rangeFromNode(n),
+					})
+				}
+				if cb := positionalArgument(n, 1); isInlineFunction(cb) && !hasTryStatement(cb.ChildByFieldName("body")) {
+					diags = append(diags, r.callbackDiag(cb, "consume callback"))
+				}
+			case amqp && last == "assertQueue" && consumes:
+				opts := positionalArgument(n, 1)
+				if content(ctx.Source, objectPropertyValue(opts, "exclusive", ctx.Source)) != "true" &&
+					objectPropertyValue(opts, "deadLetterExchange", ctx.Source) == nil &&
+					!hasDeadLetter(objectPropertyValue(opts, "arguments", ctx.Source), ctx.Source) {
+					diags = append(diags, r.deadLetterDiag(n, "Queue asserted without a dead-letter exchange", "Messages nacked with requeue=false or expired are dropped, and requeueing a poison message loops forever. Set deadLetterExchange so failures are parked for inspection."))
+				}
+			case kafka && last == "run":
+				opts := positionalArgument(n, 0)
+				for _, key := range []string{"eachMessage", "eachBatch"} {
+					if h := objectPropertyValue(opts, key, ctx.Source); isInlineFunction(h) && !hasTryStatement(h.ChildByFieldName("body")) {
+						diags = append(diags, r.callbackDiag(h, key+" handler"))
+					}
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r QueueConsumerSafety) autoCommitDiag(n *sitter.Node, kind, fix string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     fmt.Sprintf("%s commits offsets automatically", kind),
+		Explanation: fmt.Sprintf("Auto-commit is on by default and commits on a timer whether or not a message was processed, so a crash mid-batch skips messages. Set %s and commit after processing.", fix),
+		Range:       rangeFromNode(n),
+	}
+}
+
+func (r QueueConsumerSafety) deadLetterDiag(n *sitter.Node, message, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     message,
+		Explanation: explanation,
+		Range:       rangeFromNode(n),
+	}
+}
+
+func (r QueueConsumerSafety) loopDiag(n *sitter.Node) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Consumer loop without error handling",
+		Explanation: "One failing message or broker hiccup raises out of the loop and stops consumption. Wrap each iteration in try/except, log, and nack or dead-letter the message instead of exiting.",
+		Range:       rangeFromNode(n),
+	}
+}
+
+func (r QueueConsumerSafety) callbackDiag(n *sitter.Node, kind string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     fmt.Sprintf("%s without error handling", kind),
+		Explanation: "An exception here escapes to the client library, which restarts the consumer or leaves the message unacknowledged. Catch errors per message and ack, nack or dead-letter explicitly.",
+		Range:       rangeFromNode(n),
+	}
+}
+
+// pythoncallbackdiags checks a pika callback passed by name or lambda.
+func (r QueueConsumerSafety) pythonCallbackDiags(cb *sitter.Node, ctx Context) []diagnostic.Diagnostic {
+	if cb == nil {
+		return nil
+	}
+	name := content(ctx.Source, cb)
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		name = name[idx+1:]
+	}
+	var diags []diagnostic.Diagnostic
+	walkFunctions(ctx.Root, func(fn *sitter.Node) {
+		if content(ctx.Source, fn.ChildByFieldName("name")) == name && !hasTryStatement(fn.ChildByFieldName("body")) {
+			diags = append(diags, r.callbackDiag(fn, "Message callback"))
+		}
+	})
+	return diags
+}
+
+// importsmodule reports an import from any of the given packages.
+func importsModule(imports map[string]string, modules ...string) bool {
+	for _, path := range imports {
+		for _, m := range modules {
+			if path == m || strings.HasPrefix(path, m+".") || strings.HasPrefix(path, m+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// callsmethod reports a call anywhere in the file whose callee ends in .method.
+func callsMethod(root *sitter.Node, method string, source []byte) bool {
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || found {
+			return
+		}
+		if (n.Type() == "call" || n.Type() == "call_expression") && strings.HasSuffix(calleeName(n, source), "."+method) {
+			found = true
+			return
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return found
+}
+
+// pollsinloop reports a poll-style call directly in the loop body.
+func pollsInLoop(loop *sitter.Node, methods []string, source []byte) bool {
+	found := false
+	walkScope(loopBody(loop), func(n *sitter.Node) bool {
+		if n.Type() == "call" || n.Type() == "call_expression" {
+			name := calleeName(n, source)
+			if idx := strings.LastIndex(name, "."); idx != -1 && matchesAny(name[idx+1:], methods...) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func hasTryStatement(body *sitter.Node) bool {
+	found := false
+	walkScope(body, func(n *sitter.Node) bool {
+		if n.Type() == "try_statement" {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// pythonconfigdict returns a dict literal passed directly or via a
+// local name; nil when the config cannot be seen.
+func pythonConfigDict(arg *sitter.Node, source []byte) *sitter.Node {
+	if arg == nil {
+		return nil
+	}
+	if arg.Type() == "identifier" {
+		scope := enclosingFunction(arg)
+		if scope == nil {
+			scope = rootOf(arg)
+		}
+		var last *sitter.Node
+		for _, a := range assignmentsTo(scope, content(source, arg), source) {
+			last = a.value
+		}
+		arg = last
+	}
+	if arg == nil || arg.Type() != "dictionary" {
+		return nil
+	}
+	return arg
+}
+
+// pythondictvalue returns the value for a string key in a dict literal.
+func pythonDictValue(dict *sitter.Node, key string, source []byte) *sitter.Node {
+	for i := 0; dict != nil && i < int(dict.NamedChildCount()); i++ {
+		pair := dict.NamedChild(i)
+		if pair != nil && pair.Type() == "pair" && stringLiteralValue(pair.ChildByFieldName("key"), source) == key {
+			return pair.ChildByFieldName("value")
+		}
+	}
+	return nil
+}
+
+func isFalseValue(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	return matchesAny(strings.Trim(content(source, n), "'\""), "false", "0")
+}
+
+// hasdeadletter checks x-dead-letter-exchange in queue arguments.
+func hasDeadLetter(args *sitter.Node, source []byte) bool {
+	return args != nil && strings.Contains(content(source, args), "x-dead-letter-exchange")
+}
+
+// istemporaryqueue skips exclusive or server-named reply queues.
+func isTemporaryQueue(call *sitter.Node, source []byte) bool {
+	if v := keywordArgumentValue(call, "exclusive", source); v != nil && content(source, v) == "True" {
+		return true
+	}
+	q := keywordArgumentValue(call, "queue", source)
+	if q == nil {
+		q = positionalArgument(call, 0)
+	}
+	return q == nil || (q.Type() == "string" && stringLiteralValue(q, source) == "")
+}
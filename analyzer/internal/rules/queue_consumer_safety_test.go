@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestQueueConsumerSafetyPython(t *testing.T) {
+	src := []byte(`
+import pika
+from kafka import KafkaConsumer
+
+consumer = KafkaConsumer("orders", group_id="workers")
+for msg in consumer:
+    handle(msg)
+
+manual = KafkaConsumer("orders", enable_auto_commit=False)
+while True:
+    try:
+        for batch in manual.poll(timeout_ms=1000).values():
+            handle(batch)
+        manual.commit()
+    except Exception:
+        log.exception("poll failed")
+
+def on_message(ch, method, props, body):
+    try:
+        handle(body)
+        ch.basic_ack(method.delivery_tag)
+    except Exception:
+        ch.basic_nack(method.delivery_tag, requeue=False)
+
+channel = pika.BlockingConnection().channel()
+channel.queue_declare(queue="jobs", arguments={"x-dead-letter-exchange": "dlx"})
+channel.queue_declare(queue="", exclusive=True)
+channel.basic_consume(queue="jobs", on_message_callback=on_message, auto_ack=True)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewQueueConsumerSafety()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestQueueConsumerSafetyJS(t *testing.T) {
+	src := []byte(`
+const amqp = require("amqplib");
+
+async function start(url) {
+  const ch = await (await amqp.connect(url)).createChannel();
+  await ch.assertQueue("jobs", { durable: true });
+  await ch.assertQueue("", { exclusive: true });
+  ch.consume("jobs", async (msg) => {
+    try {
+      await handle(msg);
+      ch.ack(msg);
+    } catch (err) {
+      ch.nack(msg, false, false);
+    }
+  });
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewQueueConsumerSafety()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestQueueConsumerSafetyPythonPublisherOnly(t *testing.T) {
+	src := []byte(`
+import pika
+
+# workers call channel.basic_consume(queue="jobs", ...) in worker.py
+channel = pika.BlockingConnection().channel()
+channel.queue_declare(queue="jobs")
+channel.basic_publish(exchange="", routing_key="jobs", body="see .basic_consume(")
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	diags, err := NewQueueConsumerSafety().Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics for a publisher, got %d: %+v", len(diags), diags)
+	}
+}
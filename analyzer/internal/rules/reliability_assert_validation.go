@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ReliabilityAssertValidation struct{}
+
+// newreliabilityassertvalidation builds rule.
+func NewReliabilityAssertValidation() Rule { return ReliabilityAssertValidation{} }
+
+func (ReliabilityAssertValidation) ID() string { return "reliability.assert_validation" }
+
+func (ReliabilityAssertValidation) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "security"},
+		Short:           "assert used for runtime validation",
+		Long:            "assert statements are stripped under python -O, so checks written with them vanish in optimised deployments.",
+	}
+}
+
+func (ReliabilityAssertValidation) Supports(language string) bool {
+	return strings.EqualFold(language, "python")
+}
+
+func (r ReliabilityAssertValidation) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r ReliabilityAssertValidation) runPython(ctx Context) []diagnostic.Diagnostic {
+	if isTestPath(ctx.Path) {
+		return nil
+	}
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "assert_statement" {
+			cond := n.NamedChild(0)
+			if cond != nil && !isTypeNarrowing(cond, ctx.Source) {
+				check := content(ctx.Source, cond)
+				if strings.Contains(check, "\n") || len(check) > 60 {
+					check = "the condition"
+				}
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "assert used for runtime validation",
+					Explanation: fmt.Sprintf("Python drops assert statements under -O or PYTHONOPTIMIZE, so %s is never checked in optimised deployments. Use `if not (...): raise` with a specific exception (ValueError, PermissionError) instead.", check),
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// istestpath matches test_x.py, x_test.py, conftest.py and files under
+// test directories. an empty path is not a test file.
+func isTestPath(path string) bool {
+	if path == "" {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	base := parts[len(parts)-1]
+	if strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py") || base == "conftest.py" {
+		return true
+	}
+	for _, dir := range parts[:len(parts)-1] {
+		if matchesAny(dir, "test", "tests", "testing", "__tests__") {
+			return true
+		}
+	}
+	return false
+}
+
+// istypenarrowing skips isinstance / is not None asserts kept for type
+// checkers rather than input validation.
+func isTypeNarrowing(cond *sitter.Node, source []byte) bool {
+	switch cond.Type() {
+	case "call":
+		return calleeName(cond, source) == "isinstance"
+	case "comparison_operator":
+		text := content(source, cond)
+		return strings.HasSuffix(text, " is not None")
+	}
+	return false
+}
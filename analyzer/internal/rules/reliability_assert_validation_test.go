@@ -0,0 +1,40 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestReliabilityAssertValidation(t *testing.T) {
+	src := []byte(`
+def delete_account(user, account):
+    assert user.is_admin, "admin only"
+    assert isinstance(account, Account)
+    assert account.owner is not None
+    account.delete()
+
+def fetch(session, url):
+    response = session.get(url, timeout=5)
+    assert response.ok
+    return response.json()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewReliabilityAssertValidation()
+	diags, err := rule.Run(Context{Language: "python", Path: "app/accounts.py", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+	for _, path := range []string{"tests/accounts.py", "app/test_accounts.py", "conftest.py"} {
+		diags, _ := rule.Run(Context{Language: "python", Path: path, Root: root, Source: src})
+		if len(diags) != 0 {
+			t.Fatalf("expected no diagnostics for %s, got %d", path, len(diags))
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ReliabilityHardExit struct{}
+
+// newreliabilityhardexit builds rule.
+func NewReliabilityHardExit() Rule { return ReliabilityHardExit{} }
+
+func (ReliabilityHardExit) ID() string { return "reliability.hard_exit" }
+
+func (ReliabilityHardExit) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability"},
+		Short:           "Process exit from library code",
+		Long:            "Exiting from a function that other code imports skips cleanup and kills the host process.",
+	}
+}
+
+func (ReliabilityHardExit) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r ReliabilityHardExit) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python", "javascript", "typescript":
+		return r.run(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+var (
+	pythonExitCalls = []string{"sys.exit", "os._exit", "os.abort", "exit", "quit"}
+	jsExitCalls     = []string{"process.exit", "process.abort", "process.reallyExit"}
+)
+
+func (r ReliabilityHardExit) run(ctx Context) []diagnostic.Diagnostic {
+	// a shebang marks the whole file as a script.
+	if strings.HasPrefix(string(ctx.Source), "#!") {
+		return nil
+	}
+	python := strings.EqualFold(ctx.Language, "python")
+	exits := jsExitCalls
+	var imports map[string]string
+	if python {
+		exits = pythonExitCalls
+		imports = pythonImports(ctx.Root, ctx.Source)
+	} else {
+		imports = jsImports(ctx.Root, ctx.Source)
+	}
+	entries := entrypointNames(ctx.Root, ctx.Source)
+
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if isMainGuard(n, ctx.Source) {
+			return
+		}
+		if n.Type() == "call" || n.Type() == "call_expression" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			if matchesAny(name, exits...) && !reachedFromEntrypoint(n, entries, ctx.Source) {
+				fn := enclosingFunction(n)
+				owner := functionName(fn, ctx.Source)
+				if owner == "" {
+					owner = "an anonymous function"
+				}
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     fmt.Sprintf("%s() called from library code", name),
+					Explanation: fmt.Sprintf("%s can be imported by other code, where %s() kills the whole process and skips cleanup. Raise an error (or return a status) and exit only in the entrypoint or a __main__ / require.main guard.", owner, name),
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// reachedfromentrypoint reports top-level calls and calls inside
+// functions that the module itself runs as a script or CLI.
+func reachedFromEntrypoint(call *sitter.Node, entries map[string]struct{}, source []byte) bool {
+	fn := enclosingFunction(call)
+	if fn == nil {
+		return true
+	}
+	for ; fn != nil; fn = enclosingFunction(fn) {
+		name := functionName(fn, source)
+		if _, ok := entries[name]; ok || matchesAny(name, "main", "cli") {
+			return true
+		}
+		if isCLICallback(fn, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// entrypointnames collects functions called from __main__ / require.main
+// guards and CLI-decorated functions. other module-level calls such as
+// app = create_app() also run on import, so they do not count.
+func entrypointNames(root *sitter.Node, source []byte) map[string]struct{} {
+	out := map[string]struct{}{}
+	walkScope(root, func(n *sitter.Node) bool {
+		switch n.Type() {
+		case "if_statement":
+			if !isMainGuard(n, source) {
+				return true
+			}
+			walkScope(n, func(c *sitter.Node) bool {
+				if c.Type() == "call" || c.Type() == "call_expression" {
+					if fn := c.ChildByFieldName("function"); fn != nil && fn.Type() == "identifier" {
+						out[content(source, fn)] = struct{}{}
+					}
+				}
+				return true
+			})
+			return false
+		case "decorated_definition":
+			def := n.ChildByFieldName("definition")
+			for i := 0; def != nil && i < int(n.NamedChildCount()); i++ {
+				dec := n.NamedChild(i)
+				if dec.Type() != "decorator" {
+					continue
+				}
+				text := content(source, dec)
+				if strings.Contains(text, ".command") || strings.Contains(text, ".group") || strings.Contains(text, ".callback") {
+					out[content(source, def.ChildByFieldName("name"))] = struct{}{}
+				}
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// ismainguard matches if __name__ == "__main__" and require.main === module.
+func isMainGuard(n *sitter.Node, source []byte) bool {
+	if n.Type() != "if_statement" {
+		return false
+	}
+	cond := strings.Join(strings.Fields(content(source, n.ChildByFieldName("condition"))), "")
+	if strings.Contains(cond, "__name__") && strings.Contains(cond, "__main__") {
+		return true
+	}
+	return strings.Contains(cond, "require.main") || strings.Contains(cond, "import.meta.main")
+}
+
+// isclicallback matches program.command(...).action(fn) style handlers.
+func isCLICallback(fn *sitter.Node, source []byte) bool {
+	args := fn.Parent()
+	if args == nil || args.Type() != "arguments" || args.Parent() == nil {
+		return false
+	}
+	name := calleeName(args.Parent(), source)
+	return strings.HasSuffix(name, ".action") || strings.HasSuffix(name, ".command")
+}
+
+// functionname names declarations and functions bound to a variable or key.
+func functionName(fn *sitter.Node, source []byte) string {
+	if fn == nil {
+		return ""
+	}
+	if name := fn.ChildByFieldName("name"); name != nil {
+		return content(source, name)
+	}
+	parent := fn.Parent()
+	if parent == nil {
+		return ""
+	}
+	switch parent.Type() {
+	case "variable_declarator":
+		return content(source, parent.ChildByFieldName("name"))
+	case "pair":
+		return content(source, parent.ChildByFieldName("key"))
+	case "assignment_expression", "assignment":
+		return content(source, parent.ChildByFieldName("left"))
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestReliabilityHardExitPython(t *testing.T) {
+	src := []byte(`
+import os
+import sys
+
+def load_config(path):
+    if not os.path.exists(path):
+        sys.exit(1)
+    return path
+
+def run():
+    os._exit(2)
+
+def main():
+    sys.exit(0)
+
+def create_app():
+    if not os.environ.get("SECRET"):
+        sys.exit("SECRET is not set")
+    return App()
+
+app = create_app()
+
+if __name__ == "__main__":
+    run()
+    sys.exit(main())
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewReliabilityHardExit()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestReliabilityHardExitJS(t *testing.T) {
+	src := []byte(`
+function connect(url) {
+  if (!url) process.exit(1);
+}
+
+const start = async () => {
+  process.exit(0);
+};
+
+program.command("sync").action(() => {
+  process.exit(1);
+});
+
+if (require.main === module) {
+  start().catch(() => process.exit(1));
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewReliabilityHardExit()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(diags), diags)
+	}
+}
@@ -0,0 +1,386 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ResourceLeak struct{}
+
+// newresourceleak builds rule.
+func NewResourceLeak() Rule { return ResourceLeak{} }
+
+func (ResourceLeak) ID() string { return "resource.leak" }
+
+func (ResourceLeak) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "resources"},
+		Short:           "Resource handle may leak",
+		Long:            "Files, sockets and connections that are not closed on error paths leak descriptors.",
+	}
+}
+
+func (ResourceLeak) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r ResourceLeak) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+var pythonResourceOpeners = []string{
+	"open", "io.open", "codecs.open", "gzip.open", "bz2.open", "lzma.open", "tarfile.open",
+	"zipfile.ZipFile", "tempfile.NamedTemporaryFile", "tempfile.TemporaryFile",
+	"socket.socket", "socket.create_connection", "urllib.request.urlopen",
+	"sqlite3.connect", "psycopg2.connect", "pymysql.connect", "MySQLdb.connect",
+}
+
+// streams and sockets are checked for close/destroy/pipeline only; a
+// missing 'error' listener is errors.unhandled_emitter's finding.
+var jsResourceOpeners = []string{
+	"fs.createReadStream", "fs.createWriteStream", "fs.openSync", "fs.promises.open",
+	"fsPromises.open", "fs/promises.open", "net.connect", "net.createConnection",
+	"tls.connect",
+}
+
+// handlestate summarises what a scope does with an acquired handle.
+type handleState int
+
+const (
+	handleLeaked handleState = iota
+	handleClosedOnSuccess
+	handleSafe
+)
+
+func (r ResourceLeak) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			if matchesAny(name, pythonResourceOpeners...) {
+				if d, ok := r.check(n, name, ctx.Source, false); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r ResourceLeak) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := jsModuleCallee(calleeName(n, ctx.Source), imports)
+			if matchesAny(name, jsResourceOpeners...) {
+				stream := strings.HasPrefix(name, "fs.create") || strings.HasPrefix(name, "net.") || strings.HasPrefix(name, "tls.")
+				if d, ok := r.check(n, name, ctx.Source, stream); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// check follows one acquisition; streams are also closed by pipe().
+func (r ResourceLeak) check(call *sitter.Node, name string, source []byte, stream bool) (diagnostic.Diagnostic, bool) {
+	expr := call
+	if p := expr.Parent(); p != nil && p.Type() == "await_expression" {
+		expr = p
+	}
+	if insideWithItem(expr) {
+		return diagnostic.Diagnostic{}, false
+	}
+	parent := expr.Parent()
+	if parent == nil {
+		return diagnostic.Diagnostic{}, false
+	}
+	var handle string
+	switch parent.Type() {
+	case "return_statement", "yield", "yield_expression", "arrow_function":
+		// ownership moves to the caller.
+		return diagnostic.Diagnostic{}, false
+	case "assignment":
+		left := parent.ChildByFieldName("left")
+		if left == nil || left.Type() != "identifier" {
+			return diagnostic.Diagnostic{}, false
+		}
+		handle = content(source, left)
+	case "variable_declarator":
+		id := parent.ChildByFieldName("name")
+		if id == nil || id.Type() != "identifier" {
+			return diagnostic.Diagnostic{}, false
+		}
+		// using / await using dispose the handle at scope exit.
+		decl := strings.TrimSpace(content(source, parent.Parent()))
+		if strings.HasPrefix(decl, "using ") || strings.HasPrefix(decl, "await using ") {
+			return diagnostic.Diagnostic{}, false
+		}
+		handle = content(source, id)
+	case "argument_list", "arguments":
+		// src.pipe(dst) ends dst when src finishes.
+		if owner := parent.Parent(); owner != nil && (isHandleOwner(calleeName(owner, source)) || (stream && strings.HasSuffix(calleeName(owner, source), ".pipe"))) {
+			return diagnostic.Diagnostic{}, false
+		}
+	case "member_expression":
+		// fs.createReadStream(p).pipe(res) closes the file at end of stream.
+		if stream && chainPipes(expr, source) {
+			return diagnostic.Diagnostic{}, false
+		}
+	}
+
+	if handle == "" && stream {
+		return r.leakDiag(call, name, fmt.Sprintf("%s() stream is never piped, ended or destroyed, so its descriptor stays open.", name)), true
+	}
+	if handle == "" {
+		return r.leakDiag(call, name, fmt.Sprintf("%s() result is used inline and never closed; errors leave the handle open.", name)), true
+	}
+	scope := enclosingFunction(call)
+	if scope == nil {
+		scope = rootOf(call)
+	}
+	switch handleFate(scope, call, handle, source, stream) {
+	case handleSafe:
+		return diagnostic.Diagnostic{}, false
+	case handleClosedOnSuccess:
+		return r.leakDiag(call, name, fmt.Sprintf("%s is closed only on the success path; an exception before close() leaks it. Use %s.", handle, safeHandleIdiom(call, stream))), true
+	default:
+		return r.leakDiag(call, name, fmt.Sprintf("%s is never closed in this function. Use %s.", handle, safeHandleIdiom(call, stream))), true
+	}
+}
+
+func (r ResourceLeak) leakDiag(call *sitter.Node, name, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     fmt.Sprintf("%s handle may leak", name),
+		Explanation: explanation,
+		Range:       rangeFromNode(call),
+	}
+}
+
+func safeHandleIdiom(call *sitter.Node, stream bool) string {
+	switch {
+	case call.Type() == "call":
+		return "a with block or try/finally"
+	case stream:
+		return "pipeline(), or end()/destroy() it in a finally block"
+	}
+	return "try/finally or await using"
+}
+
+// handlefate scans later uses of handle in scope.
+func handleFate(scope, call *sitter.Node, handle string, source []byte, stream bool) handleState {
+	state := handleLeaked
+	closedInHandler := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || state == handleSafe {
+			return
+		}
+		if n.StartByte() < call.EndByte() && n.EndByte() <= call.EndByte() {
+			return
+		}
+		switch n.Type() {
+		case "return_statement", "yield":
+			if returnsName(n, handle, source) {
+				state = handleSafe
+				return
+			}
+		case "with_item":
+			if identifierUsed(n, handle, source) {
+				state = handleSafe
+				return
+			}
+		case "assignment", "assignment_expression":
+			// self.conn = conn hands the handle to an object.
+			right := n.ChildByFieldName("right")
+			left := n.ChildByFieldName("left")
+			if right != nil && left != nil && left.Type() != "identifier" && content(source, right) == handle {
+				state = handleSafe
+				return
+			}
+		case "call", "call_expression":
+			callee := calleeName(n, source)
+			if isCloseCall(n, callee, handle, source) {
+				switch {
+				case insideFinally(n):
+					state = handleSafe
+					return
+				case insideHandler(n):
+					closedInHandler = true
+				default:
+					if state == handleLeaked {
+						state = handleClosedOnSuccess
+					}
+				}
+			}
+			if passesName(n, handle, source) && (isHandleOwner(callee) || isContainerInsert(callee)) {
+				state = handleSafe
+				return
+			}
+			// piping closes a stream at the end: s.pipe(res) / src.pipe(s).
+			if stream && (callee == handle+".pipe" || (strings.HasSuffix(callee, ".pipe") && passesName(n, handle, source))) {
+				state = handleSafe
+				return
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(scope)
+	if state == handleClosedOnSuccess && closedInHandler {
+		return handleSafe
+	}
+	return state
+}
+
+func isCloseCall(call *sitter.Node, callee, handle string, source []byte) bool {
+	for _, m := range []string{".close", ".end", ".destroy", ".release", ".terminate", ".kill"} {
+		if callee == handle+m {
+			return true
+		}
+	}
+	// fs.closeSync(fd) / os.close(fd)
+	if strings.Contains(strings.ToLower(callee), "close") {
+		first := positionalArgument(call, 0)
+		return first != nil && content(source, first) == handle
+	}
+	return false
+}
+
+func isHandleOwner(callee string) bool {
+	last := callee
+	if idx := strings.LastIndex(callee, "."); idx != -1 {
+		last = callee[idx+1:]
+	}
+	return matchesAny(last, "enter_context", "closing", "aclosing", "pipeline", "finished", "callback")
+}
+
+func isContainerInsert(callee string) bool {
+	idx := strings.LastIndex(callee, ".")
+	if idx == -1 {
+		return false
+	}
+	return matchesAny(callee[idx+1:], "append", "add", "put", "push", "register", "set")
+}
+
+func passesName(call *sitter.Node, name string, source []byte) bool {
+	args := call.ChildByFieldName("arguments")
+	for i := 0; args != nil && i < int(args.NamedChildCount()); i++ {
+		if content(source, args.NamedChild(i)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func returnsName(n *sitter.Node, name string, source []byte) bool {
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		child := n.NamedChild(i)
+		if child == nil {
+			continue
+		}
+		if content(source, child) == name {
+			return true
+		}
+		switch child.Type() {
+		case "tuple", "expression_list", "list", "array", "object", "dictionary":
+			if returnsName(child, name, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func identifierUsed(n *sitter.Node, name string, source []byte) bool {
+	for _, id := range identifiersIn(n, source) {
+		if id == name {
+			return true
+		}
+	}
+	return false
+}
+
+func insideWithItem(n *sitter.Node) bool {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		switch p.Type() {
+		case "with_item":
+			return true
+		case "block", "module", "function_definition":
+			return false
+		}
+	}
+	return false
+}
+
+func insideFinally(n *sitter.Node) bool {
+	for p := n.Parent(); p != nil && !isFunctionNode(p); p = p.Parent() {
+		if p.Type() == "finally_clause" {
+			return true
+		}
+	}
+	return false
+}
+
+func insideHandler(n *sitter.Node) bool {
+	for p := n.Parent(); p != nil && !isFunctionNode(p); p = p.Parent() {
+		if p.Type() == "except_clause" || p.Type() == "catch_clause" {
+			return true
+		}
+	}
+	return false
+}
+
+// chainpipes reports a .pipe(...) call in the chain built on n.
+func chainPipes(n *sitter.Node, source []byte) bool {
+	for p := n.Parent(); p != nil && (p.Type() == "member_expression" || p.Type() == "call_expression"); p = p.Parent() {
+		if p.Type() == "member_expression" && content(source, p.ChildByFieldName("property")) == "pipe" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsmodulecallee resolves a callee through imports, mapping node: specifiers.
+func jsModuleCallee(name string, imports map[string]string) string {
+	resolved := resolveImport(name, imports)
+	resolved = strings.TrimPrefix(resolved, "node:")
+	resolved = strings.Replace(resolved, "fs/promises", "fs.promises", 1)
+	return resolved
+}
@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestResourceLeakPythonPaths(t *testing.T) {
+	src := []byte(`
+def closed_on_success(path):
+    f = open(path)
+    data = f.read()
+    f.close()
+    return data
+
+def with_block(path):
+    with open(path) as f:
+        return f.read()
+
+def try_finally(path):
+    f = open(path)
+    try:
+        return f.read()
+    finally:
+        f.close()
+
+def inline(path):
+    return json.load(open(path))
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewResourceLeak()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+}
+
+func TestResourceLeakJSStreams(t *testing.T) {
+	src := []byte(`
+const fs = require("fs");
+const net = require("net");
+const { pipeline } = require("stream");
+function serve(path, res) {
+  fs.createReadStream(path).pipe(res);
+}
+function copy(src, res, done) {
+  const input = fs.createReadStream(src);
+  pipeline(input, res, done);
+}
+function save(dest, data) {
+  const out = fs.createWriteStream(dest);
+  out.write(data);
+}
+function ping(port) {
+  const sock = net.connect(port);
+  sock.on("error", log);
+  sock.write("ping");
+}
+function read(path) {
+  const fd = fs.openSync(path, "r");
+  return fs.readSync(fd, buf);
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewResourceLeak()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// piped and pipeline()d streams close; an 'error' listener alone does not.
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
@@ -0,0 +1,397 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type ResourceUnboundedRead struct{}
+
+// newresourceunboundedread builds rule.
+func NewResourceUnboundedRead() Rule { return ResourceUnboundedRead{} }
+
+func (ResourceUnboundedRead) ID() string { return "resource.unbounded_read" }
+
+func (ResourceUnboundedRead) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "resources"},
+		Short:           "External data read without a size limit",
+		Long:            "Reading whole bodies, uploads or user-chosen files into memory lets one large input exhaust the process.",
+	}
+}
+
+func (ResourceUnboundedRead) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r ResourceUnboundedRead) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+const (
+	pythonStreamAdvice = "Read in bounded chunks instead: pass a maximum to read(), stream with iter_content()/shutil.copyfileobj() while counting bytes, or reject early on Content-Length (Flask: MAX_CONTENT_LENGTH)."
+	jsStreamAdvice     = "Stream instead: consume res.body / the request with a byte counter and abort past a limit, pipe to a file with stream.pipeline, or reject early on Content-Length."
+)
+
+func (r ResourceUnboundedRead) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	// app.config["MAX_CONTENT_LENGTH"] caps every Flask request body.
+	capped := strings.Contains(content(ctx.Source, ctx.Root), "MAX_CONTENT_LENGTH")
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" && positionalArgument(n, 0) == nil {
+			name := calleeName(n, ctx.Source)
+			receiver, method := name, ""
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				receiver, method = name[:idx], name[idx+1:]
+			}
+			source := ""
+			switch {
+			case method == "read" && readsHTTPResponse(n, imports, ctx.Source):
+				source = "response body"
+			case method == "read" && isUploadHandle(n, receiver, ctx.Source):
+				source = "uploaded file"
+			case (name == "request.get_data" || name == "request.body") && !capped:
+				source = "request body"
+			}
+			if source != "" && !hasSizeGuard(n, ctx.Source) {
+				diags = append(diags, r.diag(n, name, source, pythonStreamAdvice))
+			}
+		}
+		// django: data = request.body is an attribute, not a call.
+		if n.Type() == "attribute" && content(ctx.Source, n) == "request.body" && !capped && !isCallee(n) && !hasSizeGuard(n, ctx.Source) {
+			diags = append(diags, diagnostic.Diagnostic{
+				RuleID:      r.ID(),
+				Message:     "request.body reads the whole request body without a size limit",
+				Explanation: "The entire request body is loaded into memory, so one oversized input can exhaust the process. Check request.META['CONTENT_LENGTH'] first and keep DATA_UPLOAD_MAX_MEMORY_SIZE set, or read the request in chunks.",
+				Range:       rangeFromNode(n),
+			})
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r ResourceUnboundedRead) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := calleeName(n, ctx.Source)
+			receiver, method := name, ""
+			if idx := strings.LastIndex(name, "."); idx != -1 {
+				receiver, method = name[:idx], name[idx+1:]
+			}
+			switch {
+			case matchesAny(method, "text", "arrayBuffer", "blob") && readsHTTPResponse(n, imports, ctx.Source) && !hasSizeGuard(n, ctx.Source):
+				diags = append(diags, r.diag(n, name, "response body", jsStreamAdvice))
+			case matchesAny(method, "on", "addListener") && isRequestStream(receiver) && stringLiteralValue(positionalArgument(n, 0), ctx.Source) == "data":
+				if cb := positionalArgument(n, 1); isInlineFunction(cb) && buffersChunks(cb, ctx.Source) && !countsBytes(cb, ctx.Source) {
+					diags = append(diags, diagnostic.Diagnostic{
+						RuleID:      r.ID(),
+						Message:     fmt.Sprintf("'data' chunks from %s buffered without a size cap", receiver),
+						Explanation: "Every chunk is appended to memory until the peer stops sending, so a large or endless body exhausts the process. Count bytes and destroy the stream past a limit, or use a body parser with a limit. " + jsStreamAdvice,
+						Range:       rangeFromNode(n),
+					})
+				}
+			case matchesAny(jsModuleCallee(name, imports), "fs.readFileSync", "fs.readFile", "fs.promises.readFile"):
+				if arg := positionalArgument(n, 0); arg != nil && isUserSuppliedPath(arg, ctx.Source) && !hasSizeGuard(n, ctx.Source) {
+					diags = append(diags, r.diag(n, name, "user-supplied file", "A caller-chosen file can be arbitrarily large and is loaded whole. Check fs.stat().size against a limit first or stream it with fs.createReadStream."))
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r ResourceUnboundedRead) diag(n *sitter.Node, name, source, advice string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     fmt.Sprintf("%s() reads the whole %s without a size limit", name, source),
+		Explanation: fmt.Sprintf("The entire %s is loaded into memory, so one oversized input can exhaust the process. %s", source, advice),
+		Range:       rangeFromNode(n),
+	}
+}
+
+// hassizeguard reports a read limit argument, an if condition on the
+// content length, or a size comparison in the enclosing function that
+// involves the content length, the read's receiver or its path argument.
+func hasSizeGuard(n *sitter.Node, source []byte) bool {
+	for _, kw := range []string{"amt", "size", "max_bytes", "max_size", "limit"} {
+		if keywordArgumentValue(n, kw, source) != nil {
+			return true
+		}
+	}
+	scope := enclosingFunction(n)
+	if isInlineFunction(n) {
+		scope = n
+	}
+	if scope == nil {
+		scope = rootOf(n)
+	}
+	subjects := guardSubjects(n, scope, source)
+	guarded := false
+	walkScope(scope, func(c *sitter.Node) bool {
+		if guarded {
+			return false
+		}
+		switch c.Type() {
+		case "comparison_operator", "binary_expression":
+			if !isSizeComparison(c, source) {
+				break
+			}
+			guarded = mentionsContentLength(strings.ToLower(content(source, c)))
+			for _, id := range identifiersIn(c, source) {
+				guarded = guarded || subjects[id]
+			}
+		case "if_statement", "while_statement":
+			guarded = mentionsContentLength(strings.ToLower(content(source, c.ChildByFieldName("condition"))))
+		}
+		return !guarded
+	})
+	return guarded
+}
+
+// guardsubjects names the read's receiver and path argument, plus locals
+// derived from them such as st = fs.statSync(path).
+func guardSubjects(n, scope *sitter.Node, source []byte) map[string]bool {
+	subjects := map[string]bool{}
+	if n.Type() != "call" && n.Type() != "call_expression" {
+		return subjects
+	}
+	if fn := n.ChildByFieldName("function"); fn != nil {
+		// resp.raw.read() -> resp
+		obj := fn.ChildByFieldName("object")
+		for obj != nil && obj.Type() != "identifier" {
+			obj = obj.ChildByFieldName("object")
+		}
+		if obj != nil {
+			subjects[content(source, obj)] = true
+		}
+	}
+	if arg := positionalArgument(n, 0); arg != nil {
+		for _, id := range identifiersIn(arg, source) {
+			subjects[id] = true
+		}
+	}
+	walkScope(scope, func(c *sitter.Node) bool {
+		var left, value *sitter.Node
+		switch c.Type() {
+		case "assignment", "assignment_expression":
+			left, value = c.ChildByFieldName("left"), c.ChildByFieldName("right")
+		case "variable_declarator":
+			left, value = c.ChildByFieldName("name"), c.ChildByFieldName("value")
+		}
+		if left == nil || left.Type() != "identifier" || value == nil {
+			return true
+		}
+		for _, id := range identifiersIn(value, source) {
+			if subjects[id] {
+				subjects[content(source, left)] = true
+				break
+			}
+		}
+		return true
+	})
+	return subjects
+}
+
+// iscallee reports n as the function of a call: request.body() rather
+// than request.body.
+func isCallee(n *sitter.Node) bool {
+	p := n.Parent()
+	if p == nil || (p.Type() != "call" && p.Type() != "call_expression") {
+		return false
+	}
+	fn := p.ChildByFieldName("function")
+	return fn != nil && fn.Equal(n)
+}
+
+func isSizeComparison(n *sitter.Node, source []byte) bool {
+	for i := 0; i < int(n.ChildCount()); i++ {
+		if op := n.Child(i); !op.IsNamed() && matchesAny(content(source, op), "<", "<=", ">", ">=") {
+			return true
+		}
+	}
+	return false
+}
+
+func mentionsContentLength(text string) bool {
+	return strings.Contains(text, "content_length") || strings.Contains(text, "content-length") || strings.Contains(text, "contentlength")
+}
+
+// readshttpresponse reports reads whose receiver is bound from an HTTP
+// call: urlopen, requests/httpx, getresponse() or fetch.
+func readsHTTPResponse(call *sitter.Node, imports map[string]string, source []byte) bool {
+	fn := call.ChildByFieldName("function")
+	if fn == nil {
+		return false
+	}
+	obj := fn.ChildByFieldName("object")
+	// resp.raw.read()
+	if obj != nil && (obj.Type() == "attribute" || obj.Type() == "member_expression") && content(source, obj.ChildByFieldName("attribute")) == "raw" {
+		obj = obj.ChildByFieldName("object")
+	}
+	obj = unwrapAwait(obj)
+	if obj == nil {
+		return false
+	}
+	if obj.Type() == "call" || obj.Type() == "call_expression" {
+		return isHTTPCall(obj, imports, source)
+	}
+	if obj.Type() != "identifier" {
+		return false
+	}
+	name := content(source, obj)
+	scope := enclosingFunction(call)
+	if scope == nil {
+		scope = rootOf(call)
+	}
+	bound := false
+	walkScope(scope, func(c *sitter.Node) bool {
+		var value *sitter.Node
+		switch c.Type() {
+		case "as_pattern":
+			// with urlopen(url) as resp:
+			if alias := c.ChildByFieldName("alias"); alias != nil && content(source, alias) == name {
+				value = c.NamedChild(0)
+			}
+		case "assignment", "assignment_expression":
+			if left := c.ChildByFieldName("left"); left != nil && content(source, left) == name {
+				value = c.ChildByFieldName("right")
+			}
+		case "variable_declarator":
+			if id := c.ChildByFieldName("name"); id != nil && content(source, id) == name {
+				value = c.ChildByFieldName("value")
+			}
+		}
+		if value = unwrapAwait(value); value != nil && (value.Type() == "call" || value.Type() == "call_expression") && isHTTPCall(value, imports, source) {
+			bound = true
+		}
+		return !bound
+	})
+	return bound
+}
+
+func unwrapAwait(n *sitter.Node) *sitter.Node {
+	for n != nil && (n.Type() == "await" || n.Type() == "await_expression" || n.Type() == "parenthesized_expression") && n.NamedChildCount() > 0 {
+		n = n.NamedChild(0)
+	}
+	return n
+}
+
+func isHTTPCall(call *sitter.Node, imports map[string]string, source []byte) bool {
+	raw := calleeName(call, source)
+	name := resolveImport(raw, imports)
+	last := name
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		last = name[idx+1:]
+	}
+	switch {
+	case matchesAny(name, "urllib.request.urlopen", "urllib2.urlopen", "urlopen", "fetch", "node-fetch", "node-fetch.default", "cross-fetch", "undici.fetch", "undici.request"):
+		return true
+	case strings.HasPrefix(name, "requests.") || strings.HasPrefix(name, "httpx."):
+		return true
+	case last == "getresponse":
+		return true
+	}
+	// session.get(...), client.post(...), http.request(...)
+	receiver := strings.ToLower(strings.TrimSuffix(raw, "."+last))
+	return matchesAny(last, "get", "post", "put", "patch", "delete", "head", "request", "send") &&
+		(strings.Contains(receiver, "session") || strings.Contains(receiver, "client") || strings.Contains(receiver, "http"))
+}
+
+// isuploadhandle matches request.files[...] and UploadFile parameters.
+func isUploadHandle(call *sitter.Node, receiver string, source []byte) bool {
+	if strings.HasPrefix(receiver, "request.files") || receiver == "request.stream" {
+		return true
+	}
+	fn := enclosingFunction(call)
+	if fn == nil || strings.Contains(receiver, ".") {
+		return false
+	}
+	if params := fn.ChildByFieldName("parameters"); params != nil && strings.Contains(content(source, params), receiver+": UploadFile") {
+		return true
+	}
+	for _, a := range assignmentsTo(fn.ChildByFieldName("body"), receiver, source) {
+		if strings.HasPrefix(content(source, a.value), "request.files") {
+			return true
+		}
+	}
+	return false
+}
+
+func isRequestStream(receiver string) bool {
+	receiver = strings.ToLower(receiver)
+	return matchesAny(receiver, "req", "request", "incoming") || strings.HasSuffix(receiver, "req") || isResponseReceiver(receiver)
+}
+
+// bufferschunks reports += or push accumulation in a data callback.
+func buffersChunks(cb *sitter.Node, source []byte) bool {
+	text := content(source, cb.ChildByFieldName("body"))
+	return strings.Contains(text, "+=") || strings.Contains(text, ".push(") || strings.Contains(text, "Buffer.concat(")
+}
+
+// countsbytes reports a length check or destroy() in a data callback.
+func countsBytes(cb *sitter.Node, source []byte) bool {
+	if hasSizeGuard(cb, source) {
+		return true
+	}
+	text := strings.ToLower(content(source, cb))
+	for _, marker := range []string{"size >", "length >", "bytes >", ".destroy("} {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isusersuppliedpath matches paths built from req.params/query/body.
+func isUserSuppliedPath(arg *sitter.Node, source []byte) bool {
+	mentionsRequest := func(text string) bool {
+		return strings.Contains(text, "req.") || strings.Contains(text, "request.")
+	}
+	if mentionsRequest(content(source, arg)) {
+		return true
+	}
+	scope := enclosingFunction(arg)
+	if arg.Type() != "identifier" || scope == nil {
+		return false
+	}
+	for _, a := range assignmentsTo(scope, content(source, arg), source) {
+		if mentionsRequest(content(source, a.value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestResourceUnboundedReadPython(t *testing.T) {
+	src := []byte(`
+from urllib.request import urlopen
+from flask import request
+
+def fetch(url):
+    with urlopen(url, timeout=5) as resp:
+        return resp.read()
+
+def fetch_capped(url):
+    with urlopen(url, timeout=5) as resp:
+        return resp.read(1_000_000)
+
+def upload():
+    doc = request.files["doc"]
+    return doc.read()
+
+def guarded():
+    if request.content_length and request.content_length > 10_000:
+        abort(413)
+    return request.get_data()
+
+def local(path):
+    with open(path) as f:
+        return f.read()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewResourceUnboundedRead()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestResourceUnboundedReadJS(t *testing.T) {
+	src := []byte(`
+const fs = require("fs");
+
+async function proxy(url) {
+  const res = await fetch(url);
+  return await res.text();
+}
+
+function collect(req, done) {
+  let body = "";
+  req.on("data", (chunk) => { body += chunk; });
+  req.on("end", () => done(body));
+}
+
+function capped(req) {
+  let size = 0;
+  req.on("data", (chunk) => {
+    size += chunk.length;
+    if (size > LIMIT) req.destroy();
+  });
+}
+
+function download(req, res) {
+  const name = req.params.name;
+  res.send(fs.readFileSync(name));
+  res.send(fs.readFileSync("static/index.html"));
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewResourceUnboundedRead()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestResourceUnboundedReadNeedsHTTPReceiverAndRealGuard(t *testing.T) {
+	src := []byte(`
+import requests
+from urllib.request import urlopen
+
+def load(p):
+    with open(p) as r:
+        return r.read()
+
+def mirror(url):
+    # TODO: check content_length against MAX_BYTES
+    resp = requests.get(url, stream=True, timeout=5)
+    return resp.raw.read()
+
+def bounded(url):
+    with urlopen(url, timeout=5) as resp:
+        return resp.read(amt=1_000_000)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewResourceUnboundedRead()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Range.Start.Line != 11 {
+		t.Fatalf("expected only the unguarded mirror read, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestResourceUnboundedReadDjangoBodyAndUnrelatedComparison(t *testing.T) {
+	src := []byte(`
+from urllib.request import urlopen
+from django.http import JsonResponse
+
+def webhook(request):
+    data = request.body
+    return JsonResponse({"n": len(data)})
+
+def capped(request):
+    if int(request.META["CONTENT_LENGTH"]) > 10_000:
+        return JsonResponse({}, status=413)
+    return JsonResponse({"n": len(request.body)})
+
+def poll(url, max_tries):
+    for i in range(10):
+        if i > max_tries:
+            break
+    return urlopen(url, timeout=5).read()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewResourceUnboundedRead()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
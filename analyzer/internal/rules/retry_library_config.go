@@ -0,0 +1,357 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type RetryLibraryConfig struct{}
+
+// newretrylibraryconfig builds rule.
+func NewRetryLibraryConfig() Rule { return RetryLibraryConfig{} }
+
+func (RetryLibraryConfig) ID() string { return "retry.library_config" }
+
+func (RetryLibraryConfig) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "retries"},
+		Short:           "Retry library configured without limits",
+		Long:            "Retry decorators and clients need a stop condition, backoff and a narrow error filter to stay safe during outages.",
+	}
+}
+
+func (RetryLibraryConfig) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r RetryLibraryConfig) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r RetryLibraryConfig) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "decorator":
+			// bare @retry has no call node, so defaults apply.
+			expr := n.NamedChild(0)
+			if expr != nil && (expr.Type() == "identifier" || expr.Type() == "attribute") {
+				if isTenacityRetry(resolveImport(content(ctx.Source, expr), imports)) {
+					diags = append(diags, r.checkTenacity(n, nil, ctx.Source)...)
+				}
+			}
+		case "call":
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			switch {
+			case isTenacityRetry(name):
+				diags = append(diags, r.checkTenacity(n, n, ctx.Source)...)
+			case name == "backoff.on_exception" || name == "backoff.on_predicate":
+				diags = append(diags, r.checkBackoff(n, name, ctx.Source)...)
+			case isURLLibRetry(name):
+				diags = append(diags, r.checkURLLibRetry(n, ctx.Source)...)
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r RetryLibraryConfig) checkTenacity(at, call *sitter.Node, source []byte) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	stop := keywordArgumentValue(call, "stop", source)
+	if stop == nil || strings.Contains(content(source, stop), "stop_never") {
+		diags = append(diags, r.unboundedDiag(at, "tenacity retries forever unless stop= is set; add stop_after_attempt() or stop_after_delay()."))
+	}
+	wait := keywordArgumentValue(call, "wait", source)
+	if wait == nil || strings.Contains(content(source, wait), "wait_none") {
+		diags = append(diags, r.noBackoffDiag(at, "Without wait= tenacity retries immediately; use wait_exponential_jitter() or wait_random_exponential()."))
+	}
+	filter := keywordArgumentValue(call, "retry", source)
+	if filter == nil || isBroadExceptionFilter(filter, source) {
+		diags = append(diags, r.everyErrorDiag(at, "tenacity retries any exception by default, including bugs like TypeError; narrow it with retry=retry_if_exception_type(...)."))
+	}
+	return diags
+}
+
+func (r RetryLibraryConfig) checkBackoff(call *sitter.Node, name string, source []byte) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	tries := keywordArgumentValue(call, "max_tries", source)
+	maxTime := keywordArgumentValue(call, "max_time", source)
+	if (tries == nil || content(source, tries) == "None") && (maxTime == nil || content(source, maxTime) == "None") {
+		diags = append(diags, r.unboundedDiag(call, "backoff retries forever unless max_tries= or max_time= is set."))
+	}
+	if name == "backoff.on_exception" {
+		exc := keywordArgumentValue(call, "exception", source)
+		if exc == nil {
+			exc = positionalArgument(call, 1)
+		}
+		if exc != nil && isBroadExceptionFilter(exc, source) {
+			diags = append(diags, r.everyErrorDiag(call, "Retrying on Exception also retries programming errors; list the transient exception types instead."))
+		}
+	}
+	return diags
+}
+
+func (r RetryLibraryConfig) checkURLLibRetry(call *sitter.Node, source []byte) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	total := keywordArgumentValue(call, "total", source)
+	if total == nil {
+		total = positionalArgument(call, 0)
+	}
+	if total != nil && content(source, total) == "None" {
+		diags = append(diags, r.unboundedDiag(call, "Retry(total=None) defers to per-category counts that default to unlimited; set an explicit total."))
+	}
+	factor := keywordArgumentValue(call, "backoff_factor", source)
+	if factor == nil || isZeroLiteral(content(source, factor)) {
+		diags = append(diags, r.noBackoffDiag(call, "urllib3 Retry defaults to backoff_factor=0, which retries back to back; set backoff_factor."))
+	}
+	return diags
+}
+
+func (r RetryLibraryConfig) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			switch jsRetryLibrary(calleeName(n, ctx.Source), imports) {
+			case "p-retry":
+				diags = append(diags, r.checkPRetry(n, ctx.Source)...)
+			case "async-retry":
+				diags = append(diags, r.checkAsyncRetry(n, ctx.Source)...)
+			case "axios-retry":
+				diags = append(diags, r.checkAxiosRetry(n, ctx.Source)...)
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r RetryLibraryConfig) checkPRetry(call *sitter.Node, source []byte) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	opts := positionalArgument(call, 1)
+	if isUnboundedRetryOptions(opts, source) {
+		diags = append(diags, r.unboundedDiag(call, "p-retry with retries: Infinity or forever: true never gives up; use a finite retries count."))
+	}
+	fn := positionalArgument(call, 0)
+	filter := objectPropertyValue(opts, "shouldRetry", source)
+	if (filter == nil && isInlineFunction(fn) && !strings.Contains(content(source, fn), "AbortError")) || isAlwaysTrue(filter, source) {
+		diags = append(diags, r.everyErrorDiag(call, "p-retry retries every thrown error; throw AbortError or pass shouldRetry for non-transient failures."))
+	}
+	return diags
+}
+
+func (r RetryLibraryConfig) checkAsyncRetry(call *sitter.Node, source []byte) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	if isUnboundedRetryOptions(positionalArgument(call, 1), source) {
+		diags = append(diags, r.unboundedDiag(call, "async-retry with retries: Infinity or forever: true never gives up; use a finite retries count."))
+	}
+	fn := positionalArgument(call, 0)
+	if isInlineFunction(fn) && !callsFirstParameter(fn, source) {
+		diags = append(diags, r.everyErrorDiag(call, "async-retry retries every error unless the callback calls bail(); bail on non-transient failures."))
+	}
+	return diags
+}
+
+func (r RetryLibraryConfig) checkAxiosRetry(call *sitter.Node, source []byte) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	opts := positionalArgument(call, 1)
+	if isUnboundedRetryOptions(opts, source) {
+		diags = append(diags, r.unboundedDiag(call, "axios-retry with retries: Infinity never gives up; use a finite retries count."))
+	}
+	if objectPropertyValue(opts, "retryDelay", source) == nil {
+		diags = append(diags, r.noBackoffDiag(call, "axios-retry defaults to no delay between attempts; set retryDelay: axiosRetry.exponentialDelay."))
+	}
+	if isAlwaysTrue(objectPropertyValue(opts, "retryCondition", source), source) {
+		diags = append(diags, r.everyErrorDiag(call, "retryCondition that always returns true also retries 4xx and non-idempotent requests; keep the default or narrow it."))
+	}
+	return diags
+}
+
+func (r RetryLibraryConfig) unboundedDiag(n *sitter.Node, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Retry configured without a stop condition",
+		Explanation: explanation,
+		Range:       rangeFromNode(n),
+	}
+}
+
+func (r RetryLibraryConfig) noBackoffDiag(n *sitter.Node, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Retry configured without backoff",
+		Explanation: explanation,
+		Range:       rangeFromNode(n),
+	}
+}
+
+func (r RetryLibraryConfig) everyErrorDiag(n *sitter.Node, explanation string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Retry configured for every error",
+		Explanation: explanation,
+		Range:       rangeFromNode(n),
+	}
+}
+
+func isTenacityRetry(name string) bool {
+	return matchesAny(name, "tenacity.retry", "tenacity.Retrying", "tenacity.AsyncRetrying")
+}
+
+func isURLLibRetry(name string) bool {
+	return matchesAny(name,
+		"urllib3.Retry",
+		"urllib3.util.Retry",
+		"urllib3.util.retry.Retry",
+		"requests.adapters.Retry",
+		"requests.packages.urllib3.util.retry.Retry",
+	)
+}
+
+// jsretrylibrary maps a callee to its retry package.
+func jsRetryLibrary(name string, imports map[string]string) string {
+	resolved := resolveImport(name, imports)
+	if resolved == name {
+		// unresolved snippets still use the conventional names.
+		switch name {
+		case "pRetry":
+			return "p-retry"
+		case "axiosRetry":
+			return "axios-retry"
+		}
+		return ""
+	}
+	resolved = strings.TrimSuffix(resolved, ".default")
+	switch resolved {
+	case "p-retry", "async-retry", "axios-retry":
+		return resolved
+	}
+	return ""
+}
+
+// isbroadexceptionfilter reports filters that match Exception or wider.
+func isBroadExceptionFilter(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "identifier", "attribute":
+		return matchesAny(content(source, n), "Exception", "BaseException", "builtins.Exception")
+	case "tuple", "parenthesized_expression", "list":
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			if isBroadExceptionFilter(n.NamedChild(i), source) {
+				return true
+			}
+		}
+	case "call":
+		name := calleeName(n, source)
+		if strings.HasSuffix(name, "retry_if_exception_type") {
+			first := positionalArgument(n, 0)
+			return first == nil || isBroadExceptionFilter(first, source)
+		}
+		if strings.HasSuffix(name, "retry_if_exception") {
+			return isAlwaysTrue(positionalArgument(n, 0), source)
+		}
+	}
+	return false
+}
+
+func isUnboundedRetryOptions(opts *sitter.Node, source []byte) bool {
+	if opts == nil {
+		return false
+	}
+	if forever := objectPropertyValue(opts, "forever", source); forever != nil && content(source, forever) == "true" {
+		return true
+	}
+	retries := objectPropertyValue(opts, "retries", source)
+	return retries != nil && matchesAny(content(source, retries),
+		"Infinity",
+		"Number.POSITIVE_INFINITY",
+		"Number.MAX_SAFE_INTEGER",
+		"Number.MAX_VALUE",
+	)
+}
+
+func isInlineFunction(n *sitter.Node) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "arrow_function", "function", "function_expression", "lambda":
+		return true
+	}
+	return false
+}
+
+// isalwaystrue reports callbacks like () => true or lambda e: True.
+func isAlwaysTrue(n *sitter.Node, source []byte) bool {
+	if !isInlineFunction(n) {
+		return false
+	}
+	body := n.ChildByFieldName("body")
+	text := strings.TrimSpace(content(source, body))
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "{"), "}")
+	text = strings.TrimSuffix(strings.TrimSpace(text), ";")
+	text = strings.TrimSpace(strings.TrimPrefix(text, "return"))
+	return text == "true" || text == "True"
+}
+
+// callsfirstparameter reports if fn invokes its first parameter, e.g. bail().
+func callsFirstParameter(fn *sitter.Node, source []byte) bool {
+	params := fn.ChildByFieldName("parameters")
+	var first *sitter.Node
+	if params != nil && params.NamedChildCount() > 0 {
+		first = params.NamedChild(0)
+	} else {
+		first = fn.ChildByFieldName("parameter")
+	}
+	if first == nil {
+		return false
+	}
+	name := content(source, first)
+	if first.Type() != "identifier" {
+		name = content(source, first.ChildByFieldName("pattern"))
+	}
+	if name == "" {
+		return false
+	}
+	return strings.Contains(content(source, fn.ChildByFieldName("body")), name+"(")
+}
+
+func isZeroLiteral(text string) bool {
+	switch strings.TrimSpace(text) {
+	case "0", "0.0", "0.", "None":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestRetryLibraryConfigTenacityDefaults(t *testing.T) {
+	src := []byte(`
+from tenacity import retry
+
+@retry
+def fetch():
+    pass
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryLibraryConfig()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	assertRetryMessages(t, diags,
+		"Retry configured without a stop condition",
+		"Retry configured without backoff",
+		"Retry configured for every error",
+	)
+}
+
+func TestRetryLibraryConfigAxiosRetryConfigured(t *testing.T) {
+	src := []byte(`
+import axiosRetry from "axios-retry";
+axiosRetry(client, { retries: 3, retryDelay: axiosRetry.exponentialDelay });
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryLibraryConfig()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %d", len(diags))
+	}
+}
+
+func TestRetryLibraryConfigPython(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"tenacity bounded with jitter and predicate", `
+from tenacity import retry, retry_if_exception_type, stop_after_attempt, wait_random_exponential
+
+@retry(stop=stop_after_attempt(5), wait=wait_random_exponential(max=30), retry=retry_if_exception_type(ConnectionError))
+def fetch():
+    pass
+`, nil},
+		{"tenacity stop_never and broad predicate", `
+import tenacity
+
+@tenacity.retry(stop=tenacity.stop_never, wait=tenacity.wait_exponential_jitter(), retry=tenacity.retry_if_exception_type(Exception))
+def fetch():
+    pass
+`, []string{"Retry configured without a stop condition", "Retry configured for every error"}},
+		{"backoff on Exception without limits", `
+import backoff
+
+@backoff.on_exception(backoff.expo, Exception)
+def fetch():
+    pass
+`, []string{"Retry configured without a stop condition", "Retry configured for every error"}},
+		{"backoff bounded on transient errors", `
+import backoff
+import requests
+
+@backoff.on_exception(backoff.expo, requests.ConnectionError, max_tries=5, jitter=backoff.full_jitter)
+def fetch():
+    pass
+`, nil},
+		{"urllib3 Retry unbounded without backoff", `
+from urllib3.util.retry import Retry
+
+policy = Retry(total=None)
+`, []string{"Retry configured without a stop condition", "Retry configured without backoff"}},
+		{"urllib3 Retry bounded with backoff", `
+from urllib3.util.retry import Retry
+
+policy = Retry(total=5, backoff_factor=0.5, status_forcelist=[502, 503])
+`, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := []byte(tc.src)
+			root, err := ts.Parse("python", src)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			diags, err := NewRetryLibraryConfig().Run(Context{Language: "python", Root: root, Source: src})
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			assertRetryMessages(t, diags, tc.want...)
+		})
+	}
+}
+
+func TestRetryLibraryConfigJS(t *testing.T) {
+	cases := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"p-retry forever on every error", `
+import pRetry from "p-retry";
+await pRetry(() => fetch(url), { retries: Infinity });
+`, []string{"Retry configured without a stop condition", "Retry configured for every error"}},
+		{"p-retry bounded with shouldRetry", `
+import pRetry from "p-retry";
+await pRetry(() => fetch(url), { retries: 5, shouldRetry: (err) => err.code === "ECONNRESET" });
+`, nil},
+		{"async-retry forever without bail", `
+const retry = require("async-retry");
+await retry(async () => fetch(url), { forever: true });
+`, []string{"Retry configured without a stop condition", "Retry configured for every error"}},
+		{"async-retry bounded with bail", `
+const retry = require("async-retry");
+await retry(async (bail) => {
+  const res = await fetch(url);
+  if (res.status === 404) bail(new Error("missing"));
+  return res;
+}, { retries: 3, randomize: true });
+`, nil},
+		{"axios-retry always retrying", `
+import axiosRetry from "axios-retry";
+axiosRetry(client, { retries: Infinity, retryCondition: () => true });
+`, []string{"Retry configured without a stop condition", "Retry configured without backoff", "Retry configured for every error"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := []byte(tc.src)
+			root, err := ts.Parse("javascript", src)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			diags, err := NewRetryLibraryConfig().Run(Context{Language: "javascript", Root: root, Source: src})
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			assertRetryMessages(t, diags, tc.want...)
+		})
+	}
+}
+
+func assertRetryMessages(t *testing.T, diags []diagnostic.Diagnostic, want ...string) {
+	t.Helper()
+	got := make([]string, 0, len(diags))
+	for _, d := range diags {
+		got = append(got, d.Message)
+	}
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	}
+}
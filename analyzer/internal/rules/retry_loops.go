@@ -0,0 +1,279 @@
+package rules
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// retryloops returns loops that look like retries: infinite loops,
+// loops named after attempts, or loops whose try block tries again.
+func retryLoops(root *sitter.Node, source []byte) []*sitter.Node {
+	var loops []*sitter.Node
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if isLoopNode(n) && isRetryLoop(n, source) {
+			loops = append(loops, n)
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(root)
+	return loops
+}
+
+func isLoopNode(n *sitter.Node) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "while_statement", "for_statement", "for_in_statement", "do_statement":
+		return true
+	}
+	return false
+}
+
+func isFunctionNode(n *sitter.Node) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "function_definition", "lambda",
+		"function_declaration", "function_expression", "function", "arrow_function",
+		"method_definition", "generator_function_declaration", "generator_function":
+		return true
+	}
+	return false
+}
+
+// loopbody returns the body block of a loop node.
+func loopBody(n *sitter.Node) *sitter.Node {
+	if n == nil {
+		return nil
+	}
+	if body := n.ChildByFieldName("body"); body != nil {
+		return body
+	}
+	if body := firstChildOfType(n, "block"); body != nil {
+		return body
+	}
+	return firstChildOfType(n, "statement_block")
+}
+
+func isRetryLoop(n *sitter.Node, source []byte) bool {
+	if isPythonForLoop(n) {
+		header := strings.ToLower(content(source, n.ChildByFieldName("left")))
+		if mentionsRetry(header) {
+			return true
+		}
+	} else {
+		if isInfiniteLoop(n, source) {
+			return true
+		}
+		header := content(source, n)
+		if body := loopBody(n); body != nil {
+			header = string(source[n.StartByte():body.StartByte()])
+		}
+		if mentionsRetry(strings.ToLower(header)) {
+			return true
+		}
+	}
+	// a try alone is a per-item batch loop; it retries only when the
+	// handler continues or success leaves the loop.
+	found := false
+	walkScope(loopBody(n), func(c *sitter.Node) bool {
+		if isLoopNode(c) {
+			return false
+		}
+		if c.Type() == "try_statement" && triesAgain(c) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// triesagain reports a handler that continues, or a break/return in the
+// try body or else clause, for the loop directly around try.
+func triesAgain(try *sitter.Node) bool {
+	for _, clause := range handlerClauses(try) {
+		if exitsLoop(handlerBody(clause), "continue_statement") {
+			return true
+		}
+	}
+	for i := 0; i < int(try.NamedChildCount()); i++ {
+		child := try.NamedChild(i)
+		if child == nil || (!isTryBody(try, child) && child.Type() != "else_clause") {
+			continue
+		}
+		if exitsLoop(child, "break_statement", "return_statement") {
+			return true
+		}
+	}
+	return false
+}
+
+// exitsloop finds one of kinds outside nested loops and functions.
+func exitsLoop(n *sitter.Node, kinds ...string) bool {
+	found := false
+	walkScope(n, func(c *sitter.Node) bool {
+		if isLoopNode(c) || c.Type() == "switch_statement" {
+			return false
+		}
+		for _, k := range kinds {
+			if c.Type() == k {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func isPythonForLoop(n *sitter.Node) bool {
+	// python for has left/right but never a condition or initializer.
+	return n.Type() == "for_statement" && n.ChildByFieldName("left") != nil
+}
+
+func mentionsRetry(text string) bool {
+	for _, word := range []string{"attempt", "retr", "tries"} {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// walkscope visits n and descendants without entering nested functions.
+// visit returns false to skip children.
+func walkScope(n *sitter.Node, visit func(*sitter.Node) bool) {
+	if n == nil {
+		return
+	}
+	if !visit(n) {
+		return
+	}
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		child := n.NamedChild(i)
+		if child == nil || isFunctionNode(child) {
+			continue
+		}
+		walkScope(child, visit)
+	}
+}
+
+// enclosingfunction returns the nearest function around n, or nil.
+func enclosingFunction(n *sitter.Node) *sitter.Node {
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if isFunctionNode(p) {
+			return p
+		}
+	}
+	return nil
+}
+
+// sleepdelay returns the delay argument when call pauses execution.
+func sleepDelay(call *sitter.Node, source []byte) *sitter.Node {
+	name := calleeName(call, source)
+	last := name
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		last = name[idx+1:]
+	}
+	switch strings.ToLower(last) {
+	case "sleep", "delay":
+		return positionalArgument(call, 0)
+	case "settimeout":
+		// promisified setTimeout(ms) vs setTimeout(cb, ms).
+		if d := positionalArgument(call, 1); d != nil {
+			return d
+		}
+		return positionalArgument(call, 0)
+	}
+	return nil
+}
+
+// assignment is one write to a name.
+type assignment struct {
+	node     *sitter.Node
+	value    *sitter.Node
+	operator string
+}
+
+// assignmentsto collects writes to name within scope, skipping nested functions.
+func assignmentsTo(scope *sitter.Node, name string, source []byte) []assignment {
+	var out []assignment
+	walkScope(scope, func(n *sitter.Node) bool {
+		switch n.Type() {
+		case "assignment", "assignment_expression", "augmented_assignment", "augmented_assignment_expression":
+			left := n.ChildByFieldName("left")
+			if left != nil && targetsName(left, name, source) {
+				op := "="
+				if o := n.ChildByFieldName("operator"); o != nil {
+					op = content(source, o)
+				}
+				out = append(out, assignment{node: n, value: n.ChildByFieldName("right"), operator: op})
+			}
+		case "variable_declarator":
+			id := n.ChildByFieldName("name")
+			if id != nil && id.Type() == "identifier" && content(source, id) == name {
+				out = append(out, assignment{node: n, value: n.ChildByFieldName("value"), operator: "="})
+			}
+		case "update_expression":
+			arg := n.ChildByFieldName("argument")
+			if arg != nil && content(source, arg) == name {
+				out = append(out, assignment{node: n, operator: "++"})
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// targetsname reports if an assignment target binds name, including tuples.
+func targetsName(left *sitter.Node, name string, source []byte) bool {
+	switch left.Type() {
+	case "identifier":
+		return content(source, left) == name
+	case "pattern_list", "tuple_pattern", "list_pattern", "array_pattern":
+		for i := 0; i < int(left.NamedChildCount()); i++ {
+			if targetsName(left.NamedChild(i), name, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// identifiersin returns identifier names used in n.
+func identifiersIn(n *sitter.Node, source []byte) []string {
+	var out []string
+	seen := map[string]struct{}{}
+	var walk func(c *sitter.Node)
+	walk = func(c *sitter.Node) {
+		if c == nil {
+			return
+		}
+		if c.Type() == "identifier" {
+			name := content(source, c)
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				out = append(out, name)
+			}
+			return
+		}
+		if c.Type() == "attribute" || c.Type() == "member_expression" {
+			// self.delay reads a field, not a local.
+			walk(c.ChildByFieldName("object"))
+			return
+		}
+		for i := 0; i < int(c.NamedChildCount()); i++ {
+			walk(c.NamedChild(i))
+		}
+	}
+	walk(n)
+	return out
+}
@@ -0,0 +1,232 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type RetryNoJitter struct{}
+
+// newretrynojitter builds rule.
+func NewRetryNoJitter() Rule { return RetryNoJitter{} }
+
+func (RetryNoJitter) ID() string { return "retry.no_jitter" }
+
+func (RetryNoJitter) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "retries"},
+		Short:           "Retry delay without jitter",
+		Long:            "Retries that sleep a fixed or deterministic delay synchronise clients into thundering herds.",
+	}
+}
+
+func (RetryNoJitter) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r RetryNoJitter) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python", "javascript", "typescript":
+		return r.run(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// run shares one pass; sleep and assignment helpers cover both grammars.
+func (r RetryNoJitter) run(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	seen := map[uint32]struct{}{}
+	for _, loop := range retryLoops(ctx.Root, ctx.Source) {
+		var visit func(n *sitter.Node) bool
+		visit = func(n *sitter.Node) bool {
+			if fn := promiseExecutor(n, ctx.Source); fn != nil {
+				// await new Promise(r => setTimeout(r, ms)) sleeps inside a callback.
+				walkScope(fn.ChildByFieldName("body"), visit)
+				return true
+			}
+			if n.Type() != "call" && n.Type() != "call_expression" {
+				return true
+			}
+			delay := sleepDelay(n, ctx.Source)
+			if delay == nil {
+				return true
+			}
+			// nested retry loops would report the same sleep twice.
+			if _, ok := seen[n.StartByte()]; ok {
+				return true
+			}
+			seen[n.StartByte()] = struct{}{}
+			switch classifyDelay(delay, loop, ctx.Source) {
+			case delayFixed:
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "Retry sleeps a fixed delay",
+					Explanation: "Every client retries on the same beat after an outage; grow the delay exponentially and add random jitter.",
+					Range:       rangeFromNode(n),
+				})
+			case delayGrowing:
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Severity:    "info",
+					Message:     "Retry backoff without jitter",
+					Explanation: "Deterministic backoff keeps clients in lockstep; randomise the delay (full or equal jitter).",
+					Range:       rangeFromNode(n),
+				})
+			}
+			return true
+		}
+		walkScope(loopBody(loop), visit)
+	}
+	return diags
+}
+
+// promiseexecutor returns the callback of new Promise(...).
+func promiseExecutor(n *sitter.Node, source []byte) *sitter.Node {
+	if n.Type() != "new_expression" || content(source, n.ChildByFieldName("constructor")) != "Promise" {
+		return nil
+	}
+	fn := positionalArgument(n, 0)
+	if !isInlineFunction(fn) {
+		return nil
+	}
+	return fn
+}
+
+type delayKind int
+
+const (
+	delayUnknown delayKind = iota
+	delayFixed
+	delayGrowing
+	delayJittered
+)
+
+// classifydelay decides if a sleep delay is constant, growing or randomised.
+// delays from unknown calls or parameters are left alone.
+func classifyDelay(delay, loop *sitter.Node, source []byte) delayKind {
+	scope := enclosingFunction(loop)
+	if scope == nil {
+		scope = rootOf(loop)
+	}
+	if hasRandomness(content(source, delay)) {
+		return delayJittered
+	}
+	values := []*sitter.Node{delay}
+	growing := hasExponent(content(source, delay))
+	loopVars := map[string]struct{}{}
+	if left := loop.ChildByFieldName("left"); left != nil {
+		for _, name := range identifiersIn(left, source) {
+			loopVars[name] = struct{}{}
+		}
+	}
+	for _, name := range identifiersIn(delay, source) {
+		if _, ok := loopVars[name]; ok {
+			growing = true
+			continue
+		}
+		if isConstantName(name) {
+			continue
+		}
+		writes := assignmentsTo(scope, name, source)
+		if len(writes) == 0 {
+			if isKnownMathName(name) {
+				continue
+			}
+			return delayUnknown
+		}
+		for _, w := range writes {
+			if w.value != nil {
+				values = append(values, w.value)
+			}
+			if withinNode(w.node, loop) {
+				growing = true
+			}
+		}
+	}
+	for _, v := range values {
+		if hasRandomness(content(source, v)) {
+			return delayJittered
+		}
+	}
+	for _, v := range values {
+		if hasOpaqueCall(v, source) {
+			return delayUnknown
+		}
+	}
+	if growing {
+		return delayGrowing
+	}
+	return delayFixed
+}
+
+func hasRandomness(text string) bool {
+	text = strings.ToLower(text)
+	for _, word := range []string{"random", "jitter", "uniform", "randint"} {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasExponent(text string) bool {
+	return strings.Contains(text, "**") || strings.Contains(text, "pow(") || strings.Contains(text, "<<")
+}
+
+// hasopaquecall reports calls other than simple math around the delay.
+func hasOpaqueCall(n *sitter.Node, source []byte) bool {
+	found := false
+	walkScope(n, func(c *sitter.Node) bool {
+		if c.Type() == "call" || c.Type() == "call_expression" {
+			if !isKnownMathName(calleeName(c, source)) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func isKnownMathName(name string) bool {
+	return matchesAny(name,
+		"min", "max", "int", "float", "round", "abs", "pow",
+		"Math", "Math.min", "Math.max", "Math.pow", "Math.floor", "Math.ceil", "Math.round",
+		"math", "math.pow", "math.floor", "math.ceil", "timedelta", "datetime.timedelta",
+	)
+}
+
+// isconstantname reports ALL_CAPS names treated as constants.
+func isConstantName(name string) bool {
+	hasLetter := false
+	for _, ch := range name {
+		if ch >= 'a' && ch <= 'z' {
+			return false
+		}
+		if ch >= 'A' && ch <= 'Z' {
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
+
+func withinNode(n, outer *sitter.Node) bool {
+	if n == nil || outer == nil {
+		return false
+	}
+	return n.StartByte() >= outer.StartByte() && n.EndByte() <= outer.EndByte()
+}
+
+func rootOf(n *sitter.Node) *sitter.Node {
+	for n.Parent() != nil {
+		n = n.Parent()
+	}
+	return n
+}
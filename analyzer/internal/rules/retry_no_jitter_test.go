@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestRetryNoJitterFixedSleep(t *testing.T) {
+	src := []byte(`
+for attempt in range(5):
+    try:
+        call()
+        break
+    except IOError:
+        time.sleep(1)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryNoJitter()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+}
+
+func TestRetryNoJitterRandomisedBackoff(t *testing.T) {
+	src := []byte(`
+for attempt in range(5):
+    try:
+        call()
+        break
+    except IOError:
+        time.sleep(random.uniform(0, 2 ** attempt))
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryNoJitter()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %d", len(diags))
+	}
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type RetryNonIdempotent struct{}
+
+// newretrynonidempotent builds rule.
+func NewRetryNonIdempotent() Rule { return RetryNonIdempotent{} }
+
+func (RetryNonIdempotent) ID() string { return "retry.non_idempotent" }
+
+func (RetryNonIdempotent) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "retries"},
+		Short:           "Non-idempotent request retried",
+		Long:            "Retrying POST/PATCH without an idempotency key can duplicate writes when the first attempt succeeded.",
+	}
+}
+
+func (RetryNonIdempotent) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r RetryNonIdempotent) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python", "javascript", "typescript":
+		return r.run(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r RetryNonIdempotent) run(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	seen := map[uint32]struct{}{}
+	for _, loop := range retryLoops(ctx.Root, ctx.Source) {
+		scope := enclosingFunction(loop)
+		if scope == nil {
+			scope = rootOf(loop)
+		}
+		walkScope(loopBody(loop), func(n *sitter.Node) bool {
+			if n.Type() != "call" && n.Type() != "call_expression" {
+				return true
+			}
+			method := mutatingHTTPMethod(n, ctx.Source)
+			if method == "" {
+				return true
+			}
+			if _, ok := seen[n.StartByte()]; ok {
+				return true
+			}
+			seen[n.StartByte()] = struct{}{}
+			if hasIdempotencyKey(n, scope, ctx.Source) {
+				return true
+			}
+			diags = append(diags, diagnostic.Diagnostic{
+				RuleID:      r.ID(),
+				Message:     fmt.Sprintf("Retry loop repeats a %s without an idempotency key", method),
+				Explanation: "If an attempt reached the server before failing, the retry applies the write twice; send an Idempotency-Key header that stays the same across attempts.",
+				Range:       rangeFromNode(n),
+			})
+			return true
+		})
+	}
+	return diags
+}
+
+// mutatinghttpmethod returns POST or PATCH for http calls that write.
+func mutatingHTTPMethod(call *sitter.Node, source []byte) string {
+	name := calleeName(call, source)
+	last := strings.ToLower(name)
+	if idx := strings.LastIndex(last, "."); idx != -1 {
+		last = last[idx+1:]
+	} else if last != "fetch" && last != "axios" {
+		// bare post() is usually a local helper.
+		return ""
+	}
+	switch last {
+	case "post", "patch":
+		return strings.ToUpper(last)
+	case "request", "fetch", "axios":
+		// method comes from an argument: request("POST", ...) or { method: "POST" }.
+		if m := keywordArgumentValue(call, "method", source); m != nil {
+			return writeMethod(content(source, m))
+		}
+		args := call.ChildByFieldName("arguments")
+		if args == nil {
+			return ""
+		}
+		for i := 0; i < int(args.NamedChildCount()); i++ {
+			arg := args.NamedChild(i)
+			if arg == nil {
+				continue
+			}
+			if arg.Type() == "object" {
+				if m := objectPropertyValue(arg, "method", source); m != nil {
+					return writeMethod(content(source, m))
+				}
+				continue
+			}
+			if i == 0 && arg.Type() == "string" {
+				if m := writeMethod(content(source, arg)); m != "" {
+					return m
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func writeMethod(text string) string {
+	text = strings.ToUpper(strings.Trim(strings.TrimSpace(text), "'\"`"))
+	if text == "POST" || text == "PATCH" {
+		return text
+	}
+	return ""
+}
+
+// hasidempotencykey looks for the key in the call's headers or keyword
+// arguments, following a headers variable built in scope.
+func hasIdempotencyKey(call, scope *sitter.Node, source []byte) bool {
+	args := call.ChildByFieldName("arguments")
+	for i := 0; args != nil && i < int(args.NamedChildCount()); i++ {
+		arg := args.NamedChild(i)
+		if arg == nil {
+			continue
+		}
+		switch arg.Type() {
+		case "keyword_argument":
+			name := strings.ToLower(content(source, arg.ChildByFieldName("name")))
+			if strings.Contains(name, "idempotency") || (name == "headers" && headersCarryKey(arg.ChildByFieldName("value"), scope, source)) {
+				return true
+			}
+		case "object":
+			if headersCarryKey(objectPropertyValue(arg, "headers", source), scope, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func headersCarryKey(headers, scope *sitter.Node, source []byte) bool {
+	if headers == nil {
+		return false
+	}
+	mentions := func(n *sitter.Node) bool {
+		return strings.Contains(strings.ToLower(content(source, n)), "idempotency")
+	}
+	if headers.Type() != "identifier" {
+		return mentions(headers)
+	}
+	// headers = {...}; headers["Idempotency-Key"] = k; headers.set(...).
+	name := content(source, headers)
+	found := false
+	walkScope(scope, func(n *sitter.Node) bool {
+		switch n.Type() {
+		case "assignment", "assignment_expression", "variable_declarator", "call", "call_expression":
+			if strings.HasPrefix(content(source, n), name) && mentions(n) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
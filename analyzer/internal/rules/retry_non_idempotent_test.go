@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestRetryNonIdempotentFetchPost(t *testing.T) {
+	src := []byte(`
+for (let attempt = 0; attempt < 3; attempt++) {
+  try {
+    await fetch("/orders", { method: "POST", body });
+    break;
+  } catch (e) {}
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryNonIdempotent()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+}
+
+func TestRetryNonIdempotentWithKey(t *testing.T) {
+	src := []byte(`
+for attempt in range(3):
+    try:
+        requests.post(url, headers={"Idempotency-Key": key}, timeout=5)
+        break
+    except requests.RequestException:
+        continue
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryNonIdempotent()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %d", len(diags))
+	}
+}
+
+func TestRetryNonIdempotentKeyOnlyInComment(t *testing.T) {
+	src := []byte(`
+def submit(order):
+    # TODO: add an idempotency key
+    for attempt in range(3):
+        try:
+            return requests.post(URL, json=order, timeout=5)
+        except requests.RequestException:
+            continue
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryNonIdempotent()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+}
+
+func TestRetryNonIdempotentBatchLoop(t *testing.T) {
+	src := []byte(`
+for row in rows:
+    try:
+        requests.post(URL, json=row, timeout=5)
+    except requests.RequestException:
+        log.warning("row failed")
+        time.sleep(1)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for _, rule := range []Rule{NewRetryNonIdempotent(), NewRetryNoJitter()} {
+		diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+		if len(diags) != 0 {
+			t.Fatalf("%s: expected no diagnostics for a batch loop, got %d", rule.ID(), len(diags))
+		}
+	}
+}
@@ -49,22 +49,20 @@ func (r RetryUnbounded) runPython(ctx Context) []diagnostic.Diagnostic {
 		if n == nil {
 			return
 		}
-		if n.Type() == "while_statement" {
-			cond := n.ChildByFieldName("condition")
-			condText := strings.TrimSpace(content(ctx.Source, cond))
-			if strings.EqualFold(condText, "true") {
-				body := n.ChildByFieldName("body")
-				if body == nil {
-					body = firstChildOfType(n, "block")
-				}
-				if body != nil && !hasBackoff(body, ctx.Source) {
-					diags = append(diags, diagnostic.Diagnostic{
-						RuleID:      r.ID(),
-						Message:     "Retry loop without cap or backoff",
-						Explanation: "Infinite retries can amplify outages; add max attempts and backoff.",
-						Range:       rangeFromNode(n),
-					})
-				}
+		if n.Type() == "while_statement" && isInfiniteLoop(n, ctx.Source) {
+			body := loopBody(n)
+			if body != nil && !hasBackoff(body, ctx.Source) && !hasFailureExit(n, ctx.Source) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "Retry loop without cap or backoff",
+					Explanation: "Infinite retries can amplify outages; add max attempts and backoff.",
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		if n.Type() == "function_definition" {
+			if call := unboundedRecursiveRetry(n, ctx.Source); call != nil {
+				diags = append(diags, r.recursionDiag(call))
 			}
 		}
 		for i := 0; i < int(n.NamedChildCount()); i++ {
@@ -82,13 +80,11 @@ func (r RetryUnbounded) runJS(ctx Context) []diagnostic.Diagnostic {
 		if n == nil {
 			return
 		}
-		if n.Type() == "while_statement" || n.Type() == "for_statement" {
+		switch n.Type() {
+		case "while_statement", "for_statement", "do_statement":
 			if isInfiniteLoop(n, ctx.Source) {
-				body := n.ChildByFieldName("body")
-				if body == nil {
-					body = firstChildOfType(n, "statement_block")
-				}
-				if body != nil && !hasBackoff(body, ctx.Source) {
+				body := loopBody(n)
+				if body != nil && !hasBackoff(body, ctx.Source) && !hasFailureExit(n, ctx.Source) {
 					diags = append(diags, diagnostic.Diagnostic{
 						RuleID:      r.ID(),
 						Message:     "Potential unbounded retry loop",
@@ -99,6 +95,10 @@ func (r RetryUnbounded) runJS(ctx Context) []diagnostic.Diagnostic {
 					})
 				}
 			}
+		case "function_declaration", "generator_function_declaration", "method_definition", "variable_declarator":
+			if call := unboundedRecursiveRetry(n, ctx.Source); call != nil {
+				diags = append(diags, r.recursionDiag(call))
+			}
 		}
 		for i := 0; i < int(n.NamedChildCount()); i++ {
 			walk(n.NamedChild(i))
@@ -108,6 +108,16 @@ func (r RetryUnbounded) runJS(ctx Context) []diagnostic.Diagnostic {
 	return diags
 }
 
+func (r RetryUnbounded) recursionDiag(call *sitter.Node) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     "Recursive retry without attempt limit",
+		Explanation: "The error handler calls the function again with nothing counting attempts; pass a decreasing retries argument or use a bounded loop.",
+		Range:       rangeFromNode(call),
+	}
+}
+
+// hasbackoff reports sleep/backoff calls anywhere in the loop body.
 func hasBackoff(body *sitter.Node, source []byte) bool {
 	if body == nil {
 		return false
@@ -126,11 +136,6 @@ func hasBackoff(body *sitter.Node, source []byte) bool {
 				return
 			}
 		}
-		if n.Type() == "break_statement" || n.Type() == "return_statement" {
-			// break/return usually bound the loop.
-			found = true
-			return
-		}
 		for i := 0; i < int(n.NamedChildCount()); i++ {
 			walk(n.NamedChild(i))
 		}
@@ -139,17 +144,324 @@ func hasBackoff(body *sitter.Node, source []byte) bool {
 	return found
 }
 
+// hasfailureexit reports a break/return/raise that leaves this loop and
+// can run after a failure: inside an except/catch handler, under a check on
+// an attempt counter, or anywhere when the loop catches nothing.
+func hasFailureExit(loop *sitter.Node, source []byte) bool {
+	body := loopBody(loop)
+	if body == nil {
+		return false
+	}
+	counters := loopCounters(loop, source)
+	catches := false
+	walkScope(body, func(n *sitter.Node) bool {
+		if n.Type() == "except_clause" || n.Type() == "catch_clause" {
+			catches = true
+		}
+		return !catches
+	})
+
+	found := false
+	// depth counts inner loops and switches that own an unlabeled break.
+	var walk func(n *sitter.Node, depth int, inHandler, inTry, guarded bool)
+	walk = func(n *sitter.Node, depth int, inHandler, inTry, guarded bool) {
+		if n == nil || found || isFunctionNode(n) {
+			return
+		}
+		failurePath := !catches || inHandler || guarded
+		switch n.Type() {
+		case "break_statement":
+			labeled := n.ChildByFieldName("label") != nil
+			if (depth == 0 || labeled) && failurePath {
+				found = true
+			}
+			return
+		case "return_statement":
+			if failurePath {
+				found = true
+			}
+			return
+		case "raise_statement", "throw_statement":
+			// a raise inside try is caught by the loop's own handler.
+			if failurePath && !inTry {
+				found = true
+			}
+			return
+		case "while_statement", "for_statement", "for_in_statement", "do_statement", "switch_statement":
+			depth++
+		case "except_clause", "catch_clause":
+			inHandler = true
+			inTry = false
+		case "else_clause":
+			// try/else runs on success only.
+			if p := n.Parent(); p != nil && p.Type() == "try_statement" {
+				inHandler = false
+			}
+		case "if_statement":
+			cond := n.ChildByFieldName("condition")
+			for _, name := range identifiersIn(cond, source) {
+				if _, ok := counters[name]; ok {
+					guarded = true
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			childInTry := inTry
+			if n.Type() == "try_statement" && child != nil && isTryBody(n, child) {
+				childInTry = true
+			}
+			walk(child, depth, inHandler, childInTry, guarded)
+		}
+	}
+	for i := 0; i < int(body.NamedChildCount()); i++ {
+		walk(body.NamedChild(i), 0, false, false, false)
+	}
+	return found
+}
+
+func isTryBody(try, child *sitter.Node) bool {
+	body := try.ChildByFieldName("body")
+	if body == nil {
+		body = firstChildOfType(try, "block")
+	}
+	return body != nil && body.Equal(child)
+}
+
+// loopcounters returns names that count attempts in the loop.
+func loopCounters(loop *sitter.Node, source []byte) map[string]struct{} {
+	out := map[string]struct{}{}
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || isFunctionNode(n) {
+			return
+		}
+		switch n.Type() {
+		case "augmented_assignment", "augmented_assignment_expression":
+			if left := n.ChildByFieldName("left"); left != nil {
+				out[content(source, left)] = struct{}{}
+			}
+		case "update_expression":
+			if arg := n.ChildByFieldName("argument"); arg != nil {
+				out[content(source, arg)] = struct{}{}
+			}
+		case "identifier":
+			if mentionsRetry(strings.ToLower(content(source, n))) {
+				out[content(source, n)] = struct{}{}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(loop)
+	return out
+}
+
 func isInfiniteLoop(n *sitter.Node, source []byte) bool {
 	switch n.Type() {
-	case "while_statement":
+	case "while_statement", "do_statement":
 		cond := n.ChildByFieldName("condition")
-		condText := strings.TrimSpace(strings.ToLower(content(source, cond)))
-		return condText == "true"
+		return isAlwaysTruthy(cond, source) || isUnchangedNegation(cond, n, source)
 	case "for_statement":
-		// for(;;) in js has nil condition
+		if isPythonForLoop(n) {
+			return false
+		}
+		// for(;;) in js has an empty condition
 		cond := n.ChildByFieldName("condition")
-		return cond == nil || strings.TrimSpace(content(source, cond)) == ""
+		if cond == nil || cond.Type() == "empty_statement" {
+			return true
+		}
+		text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(content(source, cond)), ";"))
+		return text == "" || isTruthyLiteral(text)
+	default:
+		return false
+	}
+}
+
+func unwrapCondition(cond *sitter.Node) *sitter.Node {
+	for cond != nil && (cond.Type() == "parenthesized_expression" || cond.Type() == "expression_statement") && cond.NamedChildCount() == 1 {
+		cond = cond.NamedChild(0)
+	}
+	return cond
+}
+
+func isAlwaysTruthy(cond *sitter.Node, source []byte) bool {
+	cond = unwrapCondition(cond)
+	if cond == nil {
+		return false
+	}
+	return isTruthyLiteral(content(source, cond))
+}
+
+func isTruthyLiteral(text string) bool {
+	text = strings.TrimSpace(text)
+	switch strings.ToLower(text) {
+	case "true", "!0", "!false", "not false", "not 0":
+		return true
+	case "0", "0.0", "":
+		return false
+	}
+	for _, ch := range text {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isunchangednegation matches while not done / while (!done) where the
+// loop never assigns done.
+func isUnchangedNegation(cond, loop *sitter.Node, source []byte) bool {
+	cond = unwrapCondition(cond)
+	if cond == nil {
+		return false
+	}
+	switch cond.Type() {
+	case "not_operator":
+	case "unary_expression":
+		if !strings.HasPrefix(strings.TrimSpace(content(source, cond)), "!") {
+			return false
+		}
 	default:
 		return false
 	}
+	arg := unwrapCondition(cond.ChildByFieldName("argument"))
+	if arg == nil || arg.Type() != "identifier" {
+		return false
+	}
+	return !isAssignedWithin(loopBody(loop), content(source, arg), source)
+}
+
+// isassignedwithin reports writes to name in n, including nested closures.
+func isAssignedWithin(n *sitter.Node, name string, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "assignment", "assignment_expression", "augmented_assignment", "augmented_assignment_expression":
+		if left := n.ChildByFieldName("left"); left != nil && targetsName(left, name, source) {
+			return true
+		}
+	case "for_statement", "for_in_statement":
+		if left := n.ChildByFieldName("left"); left != nil && targetsName(left, name, source) {
+			return true
+		}
+	case "named_expression":
+		if content(source, n.ChildByFieldName("name")) == name {
+			return true
+		}
+	}
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		if isAssignedWithin(n.NamedChild(i), name, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// unboundedrecursiveretry returns the self-call made from an error handler
+// of fn when no argument advances an attempt counter.
+func unboundedRecursiveRetry(fn *sitter.Node, source []byte) *sitter.Node {
+	name, params, body := functionParts(fn, source)
+	if name == "" || body == nil {
+		return nil
+	}
+	var handlers []*sitter.Node
+	walkScope(body, func(n *sitter.Node) bool {
+		if n.Type() == "except_clause" || n.Type() == "catch_clause" {
+			handlers = append(handlers, n)
+		}
+		return true
+	})
+	for _, h := range handlers {
+		var self *sitter.Node
+		var walk func(n *sitter.Node)
+		walk = func(n *sitter.Node) {
+			if n == nil || self != nil {
+				return
+			}
+			if n.Type() == "call" || n.Type() == "call_expression" {
+				callee := calleeName(n, source)
+				if callee == name || callee == "self."+name || callee == "this."+name || callee == "cls."+name {
+					self = n
+					return
+				}
+			}
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				walk(n.NamedChild(i))
+			}
+		}
+		walk(h)
+		if self != nil && !advancesCounter(self, params, source) {
+			return self
+		}
+	}
+	return nil
+}
+
+// functionparts returns name, parameter names and body for a function node.
+func functionParts(fn *sitter.Node, source []byte) (string, []string, *sitter.Node) {
+	target := fn
+	name := content(source, fn.ChildByFieldName("name"))
+	if fn.Type() == "variable_declarator" {
+		target = fn.ChildByFieldName("value")
+		if !isInlineFunction(target) {
+			return "", nil, nil
+		}
+	}
+	var params []string
+	if p := target.ChildByFieldName("parameters"); p != nil {
+		for i := 0; i < int(p.NamedChildCount()); i++ {
+			param := p.NamedChild(i)
+			if param == nil {
+				continue
+			}
+			if param.Type() == "identifier" {
+				params = append(params, content(source, param))
+				continue
+			}
+			// default/typed parameters carry the name in a field.
+			for _, field := range []string{"name", "pattern", "left"} {
+				if id := param.ChildByFieldName(field); id != nil && id.Type() == "identifier" {
+					params = append(params, content(source, id))
+					break
+				}
+			}
+		}
+	} else if p := target.ChildByFieldName("parameter"); p != nil {
+		params = append(params, content(source, p))
+	}
+	return name, params, target.ChildByFieldName("body")
+}
+
+func advancesCounter(call *sitter.Node, params []string, source []byte) bool {
+	args := call.ChildByFieldName("arguments")
+	if args == nil {
+		return false
+	}
+	isParam := map[string]struct{}{}
+	for _, p := range params {
+		isParam[p] = struct{}{}
+	}
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil || found {
+			return
+		}
+		if n.Type() == "binary_operator" || n.Type() == "binary_expression" {
+			for _, id := range identifiersIn(n, source) {
+				if _, ok := isParam[id]; ok {
+					found = true
+					return
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(args)
+	return found
 }
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestRetryUnboundedIgnoresSuccessPathReturn(t *testing.T) {
+	src := []byte(`
+while 1:
+    try:
+        return call()
+    except Exception:
+        for handler in handlers:
+            break
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryUnbounded()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+}
+
+func TestRetryUnboundedCountedAttempts(t *testing.T) {
+	src := []byte(`
+let attempts = 0;
+do {
+  try {
+    await call();
+    break;
+  } catch (e) {
+    if (++attempts > 3) throw e;
+  }
+} while (true);
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryUnbounded()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %d", len(diags))
+	}
+}
+
+func TestRetryUnboundedRecursion(t *testing.T) {
+	src := []byte(`
+def fetch():
+    try:
+        return call()
+    except IOError:
+        return fetch()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewRetryUnbounded()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+}
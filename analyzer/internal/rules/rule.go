@@ -16,8 +16,10 @@ type Meta struct {
 // context holds rule input.
 type Context struct {
 	Language string
+	Path     string
 	Root     *sitter.Node
 	Source   []byte
+	Handlers []Handler
 }
 
 // rule is one check.
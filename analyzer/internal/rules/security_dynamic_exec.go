@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type SecurityDynamicExec struct{}
+
+// newsecuritydynamicexec builds rule.
+func NewSecurityDynamicExec() Rule { return SecurityDynamicExec{} }
+
+func (SecurityDynamicExec) ID() string { return "security.dynamic_exec" }
+
+func (SecurityDynamicExec) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"security", "dynamic-code"},
+		Short:           "Dynamic code execution",
+		Long:            "eval, exec, unsafe deserialisation and runtime compilation run whatever the input says.",
+	}
+}
+
+func (SecurityDynamicExec) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r SecurityDynamicExec) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// execsink describes one dynamic execution entry point.
+type execSink struct {
+	severity    string
+	explanation string
+}
+
+var pythonExecSinks = map[string]execSink{
+	"eval":             {"error", "eval() runs arbitrary expressions. Use ast.literal_eval for literals, or a dict of allowed operations."},
+	"exec":             {"error", "exec() runs arbitrary statements. Dispatch to known functions instead of building code as strings."},
+	"compile":          {"warning", "compile() turns strings into code objects that are usually exec'd. Keep the source static or use ast.literal_eval."},
+	"pickle.loads":     {"error", "Unpickling runs code chosen by whoever wrote the bytes. Use json or another data-only format for untrusted input."},
+	"pickle.load":      {"error", "Unpickling runs code chosen by whoever wrote the file. Use json or another data-only format for untrusted input."},
+	"dill.loads":       {"error", "dill deserialisation runs arbitrary code. Use json or another data-only format for untrusted input."},
+	"marshal.loads":    {"error", "marshal is not safe against malicious data. Use json for anything that crosses a trust boundary."},
+	"yaml.load":        {"error", "yaml.load without SafeLoader can construct arbitrary Python objects. Use yaml.safe_load or Loader=yaml.SafeLoader."},
+	"yaml.unsafe_load": {"error", "yaml.unsafe_load constructs arbitrary Python objects. Use yaml.safe_load."},
+}
+
+var jsExecSinks = map[string]execSink{
+	"eval":                {"error", "eval() runs arbitrary code with the caller's scope. Use JSON.parse for data or a lookup table of allowed functions."},
+	"Function":            {"error", "new Function(...) compiles strings into code just like eval. Use a lookup table of allowed functions instead."},
+	"setTimeout":          {"warning", "Passing a string to setTimeout evals it. Pass a function instead."},
+	"setInterval":         {"warning", "Passing a string to setInterval evals it. Pass a function instead."},
+	"vm.runInThisContext": {"error", "vm.runInThisContext is not a sandbox; code can reach the host. Avoid running untrusted code, or use an isolated process."},
+	"vm.runInNewContext":  {"error", "The vm module is not a security boundary; code can escape the context. Avoid running untrusted code, or use an isolated process."},
+	"vm.runInContext":     {"error", "The vm module is not a security boundary; code can escape the context. Avoid running untrusted code, or use an isolated process."},
+	"vm.compileFunction":  {"warning", "vm.compileFunction builds code from strings. Keep the source static or avoid runtime compilation."},
+	"vm.Script":           {"error", "vm.Script compiles code that can escape its context. Avoid running untrusted code, or use an isolated process."},
+}
+
+var safeYAMLLoaders = []string{"SafeLoader", "CSafeLoader", "BaseLoader"}
+
+func (r SecurityDynamicExec) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			name = strings.Replace(name, "cPickle.", "pickle.", 1)
+			if sink, ok := pythonExecSinks[name]; ok && !(name == "yaml.load" && hasSafeYAMLLoader(n, ctx.Source)) {
+				diags = append(diags, r.diag(n, name, sink))
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r SecurityDynamicExec) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		var name string
+		switch n.Type() {
+		case "call_expression":
+			name = calleeName(n, ctx.Source)
+		case "new_expression":
+			name = strings.TrimSpace(content(ctx.Source, n.ChildByFieldName("constructor")))
+		}
+		if name != "" {
+			for _, prefix := range []string{"window.", "globalThis.", "global."} {
+				name = strings.TrimPrefix(name, prefix)
+			}
+			name = jsModuleCallee(name, imports)
+			sink, ok := jsExecSinks[name]
+			if ok && (name == "setTimeout" || name == "setInterval") {
+				// only the string form evals.
+				first := positionalArgument(n, 0)
+				ok = first != nil && (first.Type() == "string" || first.Type() == "template_string" || first.Type() == "binary_expression")
+			}
+			if ok {
+				diags = append(diags, r.diag(n, name, sink))
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r SecurityDynamicExec) diag(n *sitter.Node, name string, sink execSink) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Severity:    sink.severity,
+		Message:     fmt.Sprintf("Dynamic code execution via %s", name),
+		Explanation: sink.explanation,
+		Range:       rangeFromNode(n),
+	}
+}
+
+// hassafeyamlloader accepts Loader=yaml.SafeLoader or a positional safe loader.
+func hasSafeYAMLLoader(call *sitter.Node, source []byte) bool {
+	loader := keywordArgumentValue(call, "Loader", source)
+	if loader == nil {
+		loader = positionalArgument(call, 1)
+	}
+	if loader == nil {
+		return false
+	}
+	text := content(source, loader)
+	if idx := strings.LastIndex(text, "."); idx != -1 {
+		text = text[idx+1:]
+	}
+	return matchesAny(text, safeYAMLLoaders...)
+}
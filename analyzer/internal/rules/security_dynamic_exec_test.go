@@ -0,0 +1,63 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestSecurityDynamicExecPython(t *testing.T) {
+	src := []byte(`
+import pickle
+import re
+import yaml
+
+def load(blob, text, expr):
+    pattern = re.compile(expr)
+    value = eval(expr)
+    obj = pickle.loads(blob)
+    unsafe = yaml.load(text)
+    safe = yaml.load(text, Loader=yaml.SafeLoader)
+    return yaml.safe_load(text)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewSecurityDynamicExec()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+	if diags[0].Severity != "error" {
+		t.Fatalf("expected eval to be an error, got %q", diags[0].Severity)
+	}
+}
+
+func TestSecurityDynamicExecJS(t *testing.T) {
+	src := []byte(`
+const vm = require("node:vm");
+
+eval(code);
+const fn = new Function("a", body);
+setTimeout("tick()", 100);
+setTimeout(tick, 100);
+vm.runInThisContext(code);
+JSON.parse(code);
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewSecurityDynamicExec()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 4 {
+		t.Fatalf("expected 4 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
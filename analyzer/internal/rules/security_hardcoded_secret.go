@@ -0,0 +1,298 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type SecurityHardcodedSecret struct{}
+
+// newsecurityhardcodedsecret builds rule.
+func NewSecurityHardcodedSecret() Rule { return SecurityHardcodedSecret{} }
+
+func (SecurityHardcodedSecret) ID() string { return "security.hardcoded_secret" }
+
+func (SecurityHardcodedSecret) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "error",
+		Tags:            []string{"security", "secrets"},
+		Short:           "Hardcoded secret",
+		Long:            "Credentials in source end up in git history, logs and every clone of the repo.",
+	}
+}
+
+func (SecurityHardcodedSecret) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r SecurityHardcodedSecret) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python", "javascript", "typescript":
+		return r.run(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// tokenformat is a well-known credential shape.
+type tokenFormat struct {
+	label   string
+	pattern *regexp.Regexp
+}
+
+var tokenFormats = []tokenFormat{
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+	{"Stripe secret key", regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{16,}\b`)},
+	{"JWT", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+}
+
+var privateKeyHeader = regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)
+
+var (
+	secretNameWords    = []string{"password", "passwd", "passphrase", "pwd", "secret", "token", "apikey", "accesskey", "privatekey", "authkey", "secretkey", "credential"}
+	secretNameSuffixes = []string{"value", "str", "string", "raw", "plain", "plaintext", "b64", "base64", "hex", "encoded", "bytes", "base", "default", "prod", "production", "dev", "staging", "test"}
+	placeholderWords   = []string{"your", "xxx", "changeme", "example", "dummy", "placeholder", "redacted", "<", "${", "{{", "%s", "{}"}
+)
+
+func (r SecurityHardcodedSecret) run(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "string" || n.Type() == "template_string" {
+			if value, ok := literalText(n, ctx.Source); ok {
+				if d, found := r.check(n, value, ctx.Source); found {
+					diags = append(diags, d)
+				}
+			}
+			return
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r SecurityHardcodedSecret) check(n *sitter.Node, value string, source []byte) (diagnostic.Diagnostic, bool) {
+	if loc := privateKeyHeader.FindStringIndex(value); loc != nil {
+		body := strings.Join(strings.Fields(value[loc[1]:]), "")
+		if len(body) >= 32 && shannonEntropy(body) >= 3.0 {
+			return r.diag(n, "Hardcoded private key", value[loc[0]:loc[1]]+"…"), true
+		}
+	}
+	for _, f := range tokenFormats {
+		token := f.pattern.FindString(value)
+		if token != "" && !isPlaceholderValue(token) && shannonEntropy(token) >= 3.0 {
+			return r.diag(n, "Hardcoded "+f.label, redactSecret(token)), true
+		}
+	}
+	name := secretTargetName(n, source)
+	if name == "" || !looksLikeSecretName(name) || !looksLikeSecretValue(value) {
+		return diagnostic.Diagnostic{}, false
+	}
+	return r.diag(n, fmt.Sprintf("Hardcoded secret in %s", name), redactSecret(value)), true
+}
+
+func (r SecurityHardcodedSecret) diag(n *sitter.Node, message, redacted string) diagnostic.Diagnostic {
+	return diagnostic.Diagnostic{
+		RuleID:      r.ID(),
+		Message:     fmt.Sprintf("%s (%s)", message, redacted),
+		Explanation: "Secrets committed to source leak through git history, forks and logs. Load it from the environment or a secret manager, and rotate this value.",
+		Range:       rangeFromNode(n),
+	}
+}
+
+// literaltext returns the contents of a string without interpolation.
+func literalText(n *sitter.Node, source []byte) (string, bool) {
+	var first, last *sitter.Node
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		child := n.NamedChild(i)
+		if child == nil {
+			continue
+		}
+		switch child.Type() {
+		case "interpolation", "template_substitution":
+			return "", false
+		case "string_content", "string_fragment", "escape_sequence":
+			if first == nil {
+				first = child
+			}
+			last = child
+		}
+	}
+	if first == nil {
+		return "", false
+	}
+	return string(source[first.StartByte():last.EndByte()]), true
+}
+
+// secrettargetname names the variable, attribute, argument or key a
+// literal is bound to.
+func secretTargetName(n *sitter.Node, source []byte) string {
+	parent := n.Parent()
+	if parent == nil {
+		return ""
+	}
+	var target *sitter.Node
+	switch parent.Type() {
+	case "assignment", "assignment_expression", "augmented_assignment":
+		if right := parent.ChildByFieldName("right"); right == nil || !right.Equal(n) {
+			return ""
+		}
+		target = parent.ChildByFieldName("left")
+	case "variable_declarator":
+		target = parent.ChildByFieldName("name")
+	case "keyword_argument", "default_parameter", "typed_default_parameter":
+		target = parent.ChildByFieldName("name")
+	case "assignment_pattern":
+		target = parent.ChildByFieldName("left")
+	case "pair":
+		if value := parent.ChildByFieldName("value"); value == nil || !value.Equal(n) {
+			return ""
+		}
+		target = parent.ChildByFieldName("key")
+	}
+	if target == nil {
+		return ""
+	}
+	switch target.Type() {
+	case "attribute":
+		target = target.ChildByFieldName("attribute")
+	case "member_expression":
+		target = target.ChildByFieldName("property")
+	case "string":
+		if key, ok := literalText(target, source); ok {
+			return key
+		}
+		return ""
+	}
+	if target == nil {
+		return ""
+	}
+	switch target.Type() {
+	case "identifier", "property_identifier", "shorthand_property_identifier":
+		return content(source, target)
+	}
+	return ""
+}
+
+// lookslikesecretname matches names whose head word is a secret word:
+// DB_PASSWORD, apiKey, client_secret_prod, not tokenizer_model or
+// password_hash_algorithm.
+func looksLikeSecretName(name string) bool {
+	words := nameWords(name)
+	for len(words) > 1 && matchesAny(words[len(words)-1], secretNameSuffixes...) {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return false
+	}
+	candidates := []string{words[len(words)-1]}
+	if len(words) > 1 {
+		// api_key, access_key, private_key
+		candidates = append(candidates, words[len(words)-2]+words[len(words)-1])
+	}
+	for _, c := range candidates {
+		for _, w := range secretNameWords {
+			if c == w || c == w+"s" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// namewords splits snake, kebab and camel case into lower-case words.
+func nameWords(name string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	runes := []rune(name)
+	for i, c := range runes {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(c) {
+			prev := runes[i-1]
+			// apiKey, oauth2Token and the P in DBPassword.
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				flush()
+			}
+		}
+		cur = append(cur, unicode.ToLower(c))
+	}
+	flush()
+	return words
+}
+
+// lookslikesecretvalue skips placeholders, env var names, paths and prose.
+func looksLikeSecretValue(value string) bool {
+	if len(value) < 8 || strings.ContainsAny(value, " \t\n") {
+		return false
+	}
+	if isPlaceholderValue(value) || strings.HasPrefix(value, "/") || strings.Contains(value, "://") || (strings.Contains(value, "_") && isConstantName(value)) {
+		return false
+	}
+	return shannonEntropy(value) >= 2.5
+}
+
+func isPlaceholderValue(value string) bool {
+	lower := strings.ToLower(value)
+	for _, w := range placeholderWords {
+		if strings.Contains(lower, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// shannonentropy returns bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := map[rune]int{}
+	total := 0
+	for _, c := range s {
+		counts[c]++
+		total++
+	}
+	entropy := 0.0
+	for _, c := range counts {
+		p := float64(c) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// redactsecret keeps a short prefix so the value can be found, never all of it.
+func redactSecret(value string) string {
+	runes := []rune(value)
+	keep := len(runes) / 4
+	if keep > 4 {
+		keep = 4
+	}
+	return fmt.Sprintf("%q, %d chars", string(runes[:keep])+"…", len(runes))
+}
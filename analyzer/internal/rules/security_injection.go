@@ -0,0 +1,232 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type SecurityInjection struct{}
+
+// newsecurityinjection builds rule.
+func NewSecurityInjection() Rule { return SecurityInjection{} }
+
+func (SecurityInjection) ID() string { return "security.injection" }
+
+func (SecurityInjection) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "error",
+		Tags:            []string{"security", "injection"},
+		Short:           "Query or command built from interpolated input",
+		Long:            "SQL and shell strings built with f-strings, % or + let input change the statement itself.",
+	}
+}
+
+func (SecurityInjection) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r SecurityInjection) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// injectionsink is the kind of interpreter a string reaches.
+type injectionSink int
+
+const (
+	sinkNone injectionSink = iota
+	sinkSQL
+	sinkShell
+)
+
+var sqlSinkMethods = []string{"execute", "executemany", "executescript", "raw", "query", "$queryRawUnsafe", "$executeRawUnsafe"}
+
+func (r SecurityInjection) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			sink := sinkNone
+			switch {
+			case name == "os.system" || name == "os.popen" || name == "subprocess.getoutput" || name == "subprocess.getstatusoutput":
+				sink = sinkShell
+			case strings.HasPrefix(name, "subprocess."):
+				if v := keywordArgumentValue(n, "shell", ctx.Source); v != nil && content(ctx.Source, v) == "True" {
+					sink = sinkShell
+				}
+			case name == "sqlalchemy.text" || isSQLSink(name):
+				sink = sinkSQL
+			}
+			if sink != sinkNone {
+				if d, ok := r.check(n, name, sink, ctx.Source); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r SecurityInjection) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := jsModuleCallee(calleeName(n, ctx.Source), imports)
+			sink := sinkNone
+			switch {
+			case matchesAny(name, "child_process.exec", "child_process.execSync"):
+				sink = sinkShell
+			case isSQLSink(name):
+				sink = sinkSQL
+			}
+			if sink != sinkNone {
+				if d, ok := r.check(n, name, sink, ctx.Source); ok {
+					diags = append(diags, d)
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r SecurityInjection) check(call *sitter.Node, name string, sink injectionSink, source []byte) (diagnostic.Diagnostic, bool) {
+	arg := positionalArgument(call, 0)
+	if arg == nil {
+		return diagnostic.Diagnostic{}, false
+	}
+	var related []diagnostic.Related
+	interpolated := isInterpolatedString(arg, source)
+	if !interpolated && arg.Type() == "identifier" {
+		// query = f"..."; cursor.execute(query)
+		scope := enclosingFunction(call)
+		if scope == nil {
+			scope = rootOf(call)
+		}
+		for _, a := range assignmentsTo(scope, content(source, arg), source) {
+			if a.node.StartByte() > call.StartByte() || a.value == nil {
+				continue
+			}
+			if isInterpolatedString(a.value, source) || (a.operator == "+=" && !isStringLiteral(a.value, source)) {
+				interpolated = true
+				related = append(related, diagnostic.Related{Message: "string built here", Range: rangeFromNode(a.node)})
+			}
+		}
+	}
+	if !interpolated {
+		return diagnostic.Diagnostic{}, false
+	}
+	d := diagnostic.Diagnostic{
+		RuleID:  r.ID(),
+		Range:   rangeFromNode(arg),
+		Related: related,
+	}
+	switch sink {
+	case sinkSQL:
+		d.Message = fmt.Sprintf("SQL passed to %s is built from interpolated values", name)
+		d.Explanation = "Values spliced into SQL can change the query. Pass them as parameters (cursor.execute(sql, (value,)), db.query(sql, [value])) or use a query builder."
+	case sinkShell:
+		d.Message = fmt.Sprintf("Shell command passed to %s is built from interpolated values", name)
+		d.Explanation = "The shell parses the whole string, so ; | $() in a value run extra commands. Pass an argument list without a shell (subprocess.run([...]), execFile/spawn)."
+	}
+	return d, true
+}
+
+func isSQLSink(name string) bool {
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return false
+	}
+	return matchesAny(name[idx+1:], sqlSinkMethods...)
+}
+
+// isinterpolatedstring matches f-strings, template literals, % / .format
+// formatting and + concatenation involving a string.
+func isInterpolatedString(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "parenthesized_expression":
+		return isInterpolatedString(n.NamedChild(0), source)
+	case "string", "template_string":
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			if t := n.NamedChild(i).Type(); t == "interpolation" || t == "template_substitution" {
+				return true
+			}
+		}
+	case "concatenated_string":
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			if isInterpolatedString(n.NamedChild(i), source) {
+				return true
+			}
+		}
+	case "binary_operator", "binary_expression":
+		left := n.ChildByFieldName("left")
+		right := n.ChildByFieldName("right")
+		if left == nil || right == nil {
+			return false
+		}
+		op := strings.TrimSpace(string(source[left.EndByte():right.StartByte()]))
+		switch op {
+		case "%":
+			return isStringLiteral(left, source) && !isStringLiteral(right, source)
+		case "+":
+			if isInterpolatedString(left, source) || isInterpolatedString(right, source) {
+				return true
+			}
+			return isStringLiteral(left, source) != isStringLiteral(right, source)
+		}
+	case "call":
+		fn := n.ChildByFieldName("function")
+		if fn != nil && fn.Type() == "attribute" && content(source, fn.ChildByFieldName("attribute")) == "format" {
+			return isStringLiteral(fn.ChildByFieldName("object"), source)
+		}
+	}
+	return false
+}
+
+func isStringLiteral(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "string", "template_string", "concatenated_string":
+		return !isInterpolatedString(n, source)
+	case "binary_operator", "binary_expression":
+		return isStringLiteral(n.ChildByFieldName("left"), source) && isStringLiteral(n.ChildByFieldName("right"), source)
+	case "parenthesized_expression":
+		return isStringLiteral(n.NamedChild(0), source)
+	}
+	return false
+}
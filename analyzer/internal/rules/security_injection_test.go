@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestSecurityInjectionPython(t *testing.T) {
+	src := []byte(`
+import os
+import subprocess
+
+def lookup(cursor, user_id, name):
+    cursor.execute(f"SELECT * FROM users WHERE id = {user_id}")
+    cursor.execute("SELECT * FROM users WHERE name = '%s'" % name)
+    cursor.execute("SELECT * FROM users WHERE id = %s", (user_id,))
+    query = "SELECT * FROM users WHERE name = '" + name + "'"
+    cursor.execute(query)
+
+def run(path):
+    subprocess.run(f"tar czf out.tgz {path}", shell=True)
+    subprocess.run(["tar", "czf", "out.tgz", path])
+    os.system("ls " + path)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewSecurityInjection()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 5 {
+		t.Fatalf("expected 5 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestSecurityInjectionJS(t *testing.T) {
+	src := []byte(`
+const { exec, execFile } = require("child_process");
+
+async function handler(db, id, file) {
+  await db.query(` + "`SELECT * FROM orders WHERE id = ${id}`" + `);
+  await db.query("SELECT * FROM orders WHERE id = $1", [id]);
+  await db.query(sql` + "`SELECT * FROM orders WHERE id = ${id}`" + `);
+  exec(` + "`convert ${file} out.png`" + `);
+  execFile("convert", [file, "out.png"]);
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewSecurityInjection()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
@@ -44,63 +44,536 @@ func (r StateGlobalMutable) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
 
 func (r StateGlobalMutable) runPython(ctx Context) []diagnostic.Diagnostic {
 	var diags []diagnostic.Diagnostic
-	for i := 0; i < int(ctx.Root.NamedChildCount()); i++ {
-		stmt := ctx.Root.NamedChild(i)
-		if stmt == nil {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	seen := map[uint32]struct{}{}
+	for _, b := range pythonModuleBindings(ctx.Root, ctx.Source) {
+		if isFinalAnnotation(b.annotation, ctx.Source) || !isMutableValue(b.value, ctx.Source, imports) {
+			continue
+		}
+		// chained assignments yield one binding per name.
+		if _, ok := seen[b.decl.StartByte()]; ok {
 			continue
 		}
-		if stmt.Type() == "expression_statement" {
-			text := strings.TrimSpace(content(ctx.Source, stmt))
-			if strings.Contains(text, "=") && (strings.Contains(text, "[") || strings.Contains(text, "{")) {
+		seen[b.decl.StartByte()] = struct{}{}
+		diags = append(diags, diagnostic.Diagnostic{
+			RuleID:      r.ID(),
+			Message:     "Module-level mutable state",
+			Explanation: "Global mutable collections can be shared implicitly across imports. Consider scoping within functions or using immutables.",
+			Severity:    "info",
+			Range:       rangeFromNode(b.decl),
+		})
+	}
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		switch n.Type() {
+		case "class_definition":
+			body := n.ChildByFieldName("body")
+			if isNestedMeta(n, ctx.Source) {
+				// django/drf class Meta: ordering = [...] is declarative config.
+				body = nil
+			}
+			for i := 0; body != nil && i < int(body.NamedChildCount()); i++ {
+				stmt := body.NamedChild(i)
+				if stmt == nil || stmt.Type() != "expression_statement" {
+					continue
+				}
+				assign := firstChildOfType(stmt, "assignment")
+				if assign == nil {
+					continue
+				}
+				// __slots__ and ALL_CAPS names are declarations, not state.
+				if name := content(ctx.Source, assign.ChildByFieldName("left")); isDunder(name) || isConstantName(name) {
+					continue
+				}
+				value := assignedValue(assign)
+				if isFinalAnnotation(assign.ChildByFieldName("type"), ctx.Source) || !isMutableValue(value, ctx.Source, imports) {
+					continue
+				}
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "Class-level mutable attribute",
+					Explanation: "Class attributes are shared by every instance; mutating one instance's copy changes all of them. Initialise it in __init__ instead.",
+					Severity:    "info",
+					Range:       rangeFromNode(assign),
+				})
+			}
+		case "function_definition":
+			if stmt := globalRebinding(n, ctx.Source); stmt != nil {
 				diags = append(diags, diagnostic.Diagnostic{
 					RuleID:      r.ID(),
-					Message:     "Module-level mutable state",
-					Explanation: "Global mutable collections can be shared implicitly across imports. Consider scoping within functions or using immutables.",
+					Message:     "Function mutates module state via global",
+					Explanation: "Rebinding module globals from functions hides writes from callers and races under threads; return the value or keep state on an object.",
 					Severity:    "info",
 					Range:       rangeFromNode(stmt),
 				})
 			}
 		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
 	}
+	walk(ctx.Root)
 	return diags
 }
 
 func (r StateGlobalMutable) runJS(ctx Context) []diagnostic.Diagnostic {
 	var diags []diagnostic.Diagnostic
-	for i := 0; i < int(ctx.Root.NamedChildCount()); i++ {
-		stmt := ctx.Root.NamedChild(i)
+	bindings := jsModuleBindings(ctx.Root, ctx.Source)
+	writes := jsFunctionWrites(ctx.Root, bindings, ctx.Source)
+	for _, b := range bindings {
+		sites := writes[b.name]
+		if len(sites) == 0 || isFrozenValue(b.value, ctx.Source) {
+			continue
+		}
+		var related []diagnostic.Related
+		reassigned := false
+		for _, site := range sites {
+			msg := "mutated here"
+			if site.reassign {
+				msg = "reassigned here"
+				reassigned = true
+			}
+			related = append(related, diagnostic.Related{Message: msg, Range: rangeFromNode(site.node)})
+		}
+		switch {
+		case reassigned && b.kind != "const":
+			diags = append(diags, diagnostic.Diagnostic{
+				RuleID:      r.ID(),
+				Message:     "Module-level binding reassigned from functions",
+				Explanation: "Every importer shares this binding; functions that reassign it create hidden coupling. Pass the value explicitly or wrap it in a factory.",
+				Severity:    "info",
+				Range:       rangeFromNode(b.decl),
+				Related:     related,
+				Tags:        []string{"state"},
+			})
+		case isJSMutableValue(b.value, ctx.Source):
+			diags = append(diags, diagnostic.Diagnostic{
+				RuleID:      r.ID(),
+				Message:     "Module-level mutable state",
+				Explanation: "Globals that hold mutable objects are easily shared across imports; prefer local scopes or factories.",
+				Severity:    "info",
+				Range:       rangeFromNode(b.decl),
+				Related:     related,
+				Tags:        []string{"state"},
+			})
+		}
+	}
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "assignment_expression" || n.Type() == "augmented_assignment_expression" {
+			left := n.ChildByFieldName("left")
+			if left != nil && left.Type() != "identifier" {
+				if obj := rootObject(left); obj != nil && matchesAny(content(ctx.Source, obj), "globalThis", "window", "global", "self") {
+					diags = append(diags, diagnostic.Diagnostic{
+						RuleID:      r.ID(),
+						Message:     "Write to the global object",
+						Explanation: "Properties on globalThis/window are process-wide state visible to every module; export the value or inject it instead.",
+						Severity:    "info",
+						Range:       rangeFromNode(n),
+						Tags:        []string{"state"},
+					})
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// jsmodulebindings finds top-level and exported var/let/const declarators.
+func jsModuleBindings(root *sitter.Node, source []byte) []binding {
+	var out []binding
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		stmt := root.NamedChild(i)
 		if stmt == nil {
 			continue
 		}
+		if stmt.Type() == "export_statement" {
+			if decl := stmt.ChildByFieldName("declaration"); decl != nil {
+				stmt = decl
+			}
+		}
 		switch stmt.Type() {
 		case "lexical_declaration", "variable_declaration":
+			kind := "var"
+			if stmt.ChildCount() > 0 {
+				kind = content(source, stmt.Child(0))
+			}
 			for j := 0; j < int(stmt.NamedChildCount()); j++ {
 				decl := stmt.NamedChild(j)
-				if decl == nil {
+				if decl == nil || decl.Type() != "variable_declarator" {
 					continue
 				}
-				init := decl.ChildByFieldName("value")
-				if init != nil && isMutableLiteral(init, ctx.Source) {
-					diags = append(diags, diagnostic.Diagnostic{
-						RuleID:      r.ID(),
-						Message:     "Module-level mutable state",
-						Explanation: "Globals that hold mutable objects are easily shared across imports; prefer local scopes or factories.",
-						Severity:    "info",
-						Range:       rangeFromNode(decl),
-						Tags:        []string{"state"},
-					})
+				name := decl.ChildByFieldName("name")
+				if name == nil || name.Type() != "identifier" {
+					continue
 				}
+				out = append(out, binding{
+					name:  content(source, name),
+					decl:  decl,
+					value: decl.ChildByFieldName("value"),
+					kind:  kind,
+				})
 			}
 		}
 	}
-	return diags
+	return out
+}
+
+// writesite is one write to a module binding from inside a function.
+type writeSite struct {
+	node     *sitter.Node
+	reassign bool
+}
+
+var jsMutatingMethods = []string{
+	"push", "pop", "shift", "unshift", "splice", "sort", "reverse", "fill", "copyWithin",
+	"set", "add", "delete", "clear",
+}
+
+// jsfunctionwrites maps binding names to writes made inside functions,
+// skipping functions that shadow the name.
+func jsFunctionWrites(root *sitter.Node, bindings []binding, source []byte) map[string][]writeSite {
+	names := map[string]struct{}{}
+	for _, b := range bindings {
+		names[b.name] = struct{}{}
+	}
+	out := map[string][]writeSite{}
+	var walk func(n *sitter.Node, inFunc bool, shadowed map[string]struct{})
+	walk = func(n *sitter.Node, inFunc bool, shadowed map[string]struct{}) {
+		if n == nil {
+			return
+		}
+		if isFunctionNode(n) {
+			inFunc = true
+			local := jsLocalNames(n, source)
+			if len(local) > 0 {
+				merged := map[string]struct{}{}
+				for k := range shadowed {
+					merged[k] = struct{}{}
+				}
+				for k := range local {
+					merged[k] = struct{}{}
+				}
+				shadowed = merged
+			}
+		}
+		if inFunc {
+			if name, reassign, ok := jsWriteTarget(n, source); ok {
+				if _, tracked := names[name]; tracked {
+					if _, hidden := shadowed[name]; !hidden {
+						out[name] = append(out[name], writeSite{node: n, reassign: reassign})
+					}
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i), inFunc, shadowed)
+		}
+	}
+	walk(root, false, map[string]struct{}{})
+	return out
+}
+
+// jswritetarget returns the root name written by n, if any.
+func jsWriteTarget(n *sitter.Node, source []byte) (string, bool, bool) {
+	switch n.Type() {
+	case "assignment_expression", "augmented_assignment_expression":
+		left := n.ChildByFieldName("left")
+		if left == nil {
+			return "", false, false
+		}
+		if left.Type() == "identifier" {
+			return content(source, left), true, true
+		}
+		if obj := rootObject(left); obj != nil && obj.Type() == "identifier" {
+			return content(source, obj), false, true
+		}
+	case "update_expression":
+		arg := n.ChildByFieldName("argument")
+		if arg != nil && arg.Type() == "identifier" {
+			return content(source, arg), true, true
+		}
+		if obj := rootObject(arg); obj != nil && obj.Type() == "identifier" {
+			return content(source, obj), false, true
+		}
+	case "unary_expression":
+		if strings.HasPrefix(content(source, n), "delete") {
+			if obj := rootObject(n.ChildByFieldName("argument")); obj != nil && obj.Type() == "identifier" {
+				return content(source, obj), false, true
+			}
+		}
+	case "call_expression":
+		fn := n.ChildByFieldName("function")
+		if fn == nil || fn.Type() != "member_expression" {
+			return "", false, false
+		}
+		if content(source, fn) == "Object.assign" {
+			if target := positionalArgument(n, 0); target != nil && target.Type() == "identifier" {
+				return content(source, target), false, true
+			}
+			return "", false, false
+		}
+		method := content(source, fn.ChildByFieldName("property"))
+		obj := fn.ChildByFieldName("object")
+		if obj != nil && obj.Type() == "identifier" && matchesAny(method, jsMutatingMethods...) {
+			return content(source, obj), false, true
+		}
+	}
+	return "", false, false
+}
+
+// rootobject walks a.b[c].d down to a.
+func rootObject(n *sitter.Node) *sitter.Node {
+	for n != nil && (n.Type() == "member_expression" || n.Type() == "subscript_expression") {
+		n = n.ChildByFieldName("object")
+	}
+	return n
+}
+
+// jslocalnames returns parameters and declarations owned by fn.
+func jsLocalNames(fn *sitter.Node, source []byte) map[string]struct{} {
+	out := map[string]struct{}{}
+	if p := fn.ChildByFieldName("parameter"); p != nil {
+		out[content(source, p)] = struct{}{}
+	}
+	if params := fn.ChildByFieldName("parameters"); params != nil {
+		for _, name := range identifiersIn(params, source) {
+			out[name] = struct{}{}
+		}
+	}
+	walkScope(fn.ChildByFieldName("body"), func(n *sitter.Node) bool {
+		var pattern *sitter.Node
+		switch n.Type() {
+		case "variable_declarator":
+			pattern = n.ChildByFieldName("name")
+		case "for_in_statement":
+			// for (x of xs) without a kind writes the outer x.
+			if n.ChildByFieldName("kind") != nil {
+				pattern = n.ChildByFieldName("left")
+			}
+		case "catch_clause":
+			pattern = n.ChildByFieldName("parameter")
+		}
+		for _, name := range patternNames(pattern, source) {
+			out[name] = struct{}{}
+		}
+		return true
+	})
+	return out
+}
+
+// patternnames lists names bound by an identifier or destructuring pattern.
+func patternNames(n *sitter.Node, source []byte) []string {
+	if n == nil {
+		return nil
+	}
+	switch n.Type() {
+	case "identifier", "shorthand_property_identifier_pattern":
+		return []string{content(source, n)}
+	case "pair_pattern":
+		return patternNames(n.ChildByFieldName("value"), source)
+	case "assignment_pattern":
+		return patternNames(n.ChildByFieldName("left"), source)
+	}
+	var out []string
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		out = append(out, patternNames(n.NamedChild(i), source)...)
+	}
+	return out
+}
+
+// isfrozenvalue matches Object.freeze(...) and `as const` literals.
+func isFrozenValue(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "call_expression":
+		return content(source, n.ChildByFieldName("function")) == "Object.freeze"
+	case "as_expression":
+		return strings.HasSuffix(strings.TrimSpace(content(source, n)), "as const")
+	}
+	return false
+}
+
+func isJSMutableValue(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "object", "array":
+		return true
+	case "new_expression":
+		return matchesAny(content(source, n.ChildByFieldName("constructor")), "Map", "Set", "WeakMap", "WeakSet", "Array", "Object")
+	case "as_expression", "satisfies_expression", "parenthesized_expression":
+		return isJSMutableValue(n.NamedChild(0), source)
+	}
+	return false
 }
 
 func isMutableLiteral(n *sitter.Node, source []byte) bool {
-	t := n.Type()
-	if t == "object" || t == "array" || t == "dictionary" || t == "list" {
+	if n == nil {
+		return false
+	}
+	switch n.Type() {
+	case "object", "array", "dictionary", "list", "set",
+		"list_comprehension", "dictionary_comprehension", "set_comprehension":
 		return true
 	}
 	text := strings.TrimSpace(content(source, n))
 	return strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[")
 }
+
+// ismutablevalue adds constructor calls like dict() or deque() to literals.
+func isMutableValue(n *sitter.Node, source []byte, imports map[string]string) bool {
+	if n == nil {
+		return false
+	}
+	if n.Type() == "call" {
+		return isMutableConstructor(resolveImport(calleeName(n, source), imports))
+	}
+	return isMutableLiteral(n, source)
+}
+
+func isMutableConstructor(name string) bool {
+	return matchesAny(name,
+		"dict", "list", "set", "bytearray",
+		"defaultdict", "OrderedDict", "Counter", "deque", "ChainMap",
+		"collections.defaultdict", "collections.OrderedDict", "collections.Counter",
+		"collections.deque", "collections.ChainMap",
+	)
+}
+
+// binding is a module-level name and the value it starts with.
+type binding struct {
+	name       string
+	decl       *sitter.Node
+	value      *sitter.Node
+	annotation *sitter.Node
+	kind       string
+}
+
+// pythonmodulebindings finds names assigned at module level, including
+// module-level if/try blocks but not defs or classes.
+func pythonModuleBindings(root *sitter.Node, source []byte) []binding {
+	var out []binding
+	var visit, visitCompound func(n *sitter.Node)
+	visit = func(block *sitter.Node) {
+		for i := 0; block != nil && i < int(block.NamedChildCount()); i++ {
+			stmt := block.NamedChild(i)
+			if stmt == nil {
+				continue
+			}
+			switch stmt.Type() {
+			case "expression_statement":
+				assign := firstChildOfType(stmt, "assignment")
+				if assign == nil {
+					continue
+				}
+				value := assignedValue(assign)
+				// a = b = [] binds both names to the same list.
+				for a := assign; a != nil && a.Type() == "assignment"; a = a.ChildByFieldName("right") {
+					left := a.ChildByFieldName("left")
+					if left == nil || left.Type() != "identifier" {
+						continue
+					}
+					out = append(out, binding{
+						name:       content(source, left),
+						decl:       assign,
+						value:      value,
+						annotation: a.ChildByFieldName("type"),
+					})
+				}
+			case "if_statement", "try_statement", "with_statement":
+				visitCompound(stmt)
+			}
+		}
+	}
+	visitCompound = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			if child == nil {
+				continue
+			}
+			switch child.Type() {
+			case "block":
+				visit(child)
+			case "elif_clause", "else_clause", "except_clause", "finally_clause":
+				visitCompound(child)
+			}
+		}
+	}
+	visit(root)
+	return out
+}
+
+// assignedvalue returns the final right-hand side of a chained assignment.
+func assignedValue(assign *sitter.Node) *sitter.Node {
+	value := assign.ChildByFieldName("right")
+	for value != nil && value.Type() == "assignment" {
+		value = value.ChildByFieldName("right")
+	}
+	return value
+}
+
+// isfinalannotation matches typing.Final constants.
+func isFinalAnnotation(n *sitter.Node, source []byte) bool {
+	if n == nil {
+		return false
+	}
+	text := strings.TrimSpace(content(source, n))
+	return text == "Final" || text == "typing.Final" || strings.HasPrefix(text, "Final[") || strings.HasPrefix(text, "typing.Final[")
+}
+
+// globalrebinding returns the global statement when fn assigns a name it
+// declares global.
+func globalRebinding(fn *sitter.Node, source []byte) *sitter.Node {
+	body := fn.ChildByFieldName("body")
+	var found *sitter.Node
+	walkScope(body, func(n *sitter.Node) bool {
+		if found != nil {
+			return false
+		}
+		if n.Type() != "global_statement" {
+			return true
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			id := n.NamedChild(i)
+			if id != nil && len(assignmentsTo(body, content(source, id), source)) > 0 {
+				found = n
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isnestedmeta matches a class Meta declared inside another class.
+func isNestedMeta(class *sitter.Node, source []byte) bool {
+	if content(source, class.ChildByFieldName("name")) != "Meta" {
+		return false
+	}
+	for p := class.Parent(); p != nil; p = p.Parent() {
+		if p.Type() == "class_definition" {
+			return true
+		}
+	}
+	return false
+}
+
+func isDunder(name string) bool {
+	return len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestStateGlobalMutablePythonConstructors(t *testing.T) {
+	src := []byte(`
+from collections import defaultdict
+TIMEOUTS = frozenset({"connect", "read"})
+RETRIES: tuple[int, ...] = (1, 2, 4)
+value = settings["key"]
+cache = dict()
+groups = defaultdict(list)
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateGlobalMutable()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+}
+
+func TestStateGlobalMutablePythonGlobalKeyword(t *testing.T) {
+	src := []byte(`
+def bump():
+    global counter
+    counter += 1
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateGlobalMutable()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+}
+
+func TestStateGlobalMutableJSMutatedFromFunction(t *testing.T) {
+	src := []byte(`
+const CONFIG = Object.freeze({ retries: 3 });
+const untouched = {};
+const cache = new Map();
+export function remember(k, v) {
+  cache.set(k, v);
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateGlobalMutable()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	if len(diags[0].Related) != 1 || diags[0].Related[0].Range.Start.Line != 5 {
+		t.Fatalf("expected related mutation site on line 5, got %+v", diags[0].Related)
+	}
+}
+
+func TestStateGlobalMutableJSShadowedByLoopAndCatch(t *testing.T) {
+	src := []byte(`
+const items = [];
+const err = {};
+export function collect(groups) {
+  for (const items of groups) {
+    items.push(1);
+  }
+  try {
+    run();
+  } catch (err) {
+    err.handled = true;
+  }
+}
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateGlobalMutable()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics for shadowed names, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestStateGlobalMutablePythonClassAttributes(t *testing.T) {
+	src := []byte(`
+class Order(models.Model):
+    __slots__ = ["id", "total"]
+    STATUSES = ["new", "paid"]
+    items = []
+
+    class Meta:
+        ordering = ["-created"]
+        indexes = [models.Index(fields=["total"])]
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateGlobalMutable()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Range.Start.Line != 4 {
+		t.Fatalf("expected only items = [], got %d: %+v", len(diags), diags)
+	}
+}
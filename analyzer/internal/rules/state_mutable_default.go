@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type StateMutableDefault struct{}
+
+// newstatemutabledefault builds rule.
+func NewStateMutableDefault() Rule { return StateMutableDefault{} }
+
+func (StateMutableDefault) ID() string { return "state.mutable_default" }
+
+func (StateMutableDefault) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"state"},
+		Short:           "Mutable default argument",
+		Long:            "Default values are evaluated once, so a mutable default is shared across every call.",
+	}
+}
+
+func (StateMutableDefault) Supports(language string) bool {
+	return strings.ToLower(language) == "python"
+}
+
+func (r StateMutableDefault) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (r StateMutableDefault) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "default_parameter" || n.Type() == "typed_default_parameter" {
+			value := n.ChildByFieldName("value")
+			if isMutableValue(value, ctx.Source, imports) {
+				name := content(ctx.Source, n.ChildByFieldName("name"))
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     fmt.Sprintf("Mutable default argument %s", name),
+					Explanation: mutableDefaultFix(n, name, content(ctx.Source, value), ctx.Source),
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// mutabledefaultfix spells out the None-sentinel rewrite for the parameter.
+func mutableDefaultFix(param *sitter.Node, name, value string, source []byte) string {
+	signature := name + "=None"
+	if typ := param.ChildByFieldName("type"); typ != nil {
+		signature = fmt.Sprintf("%s: %s | None = None", name, content(source, typ))
+	}
+	if len(value) > 40 || strings.Contains(value, "\n") {
+		value = "..."
+	}
+	return fmt.Sprintf(
+		"The default is built once at definition time and shared by every call, so writes leak between calls. Use `%s` and add `if %s is None: %s = %s` at the top of the function.",
+		signature, name, name, value,
+	)
+}
@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestStateMutableDefaultPython(t *testing.T) {
+	src := []byte(`
+def collect(x, cache={}, *, items: list = [], seen=set(), name="x", limit=None):
+    pass
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateMutableDefault()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d", len(diags))
+	}
+}
+
+func TestStateMutableDefaultPythonFix(t *testing.T) {
+	src := []byte(`
+def collect(x, cache={}, *, items: list = []):
+    pass
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	diags, err := NewStateMutableDefault().Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+	for i, want := range []string{
+		"Use `cache=None` and add `if cache is None: cache = {}` at the top of the function.",
+		"Use `items: list | None = None` and add `if items is None: items = []` at the top of the function.",
+	} {
+		if !strings.HasSuffix(diags[i].Explanation, want) {
+			t.Fatalf("diagnostic %d: expected explanation ending %q, got %q", i, want, diags[i].Explanation)
+		}
+	}
+}
+
+func TestStateMutableDefaultPythonImmutable(t *testing.T) {
+	src := []byte(`
+def collect(x, keys=(), tags=frozenset(), pair=(1, 2), frozen=frozenset({"a"}), cache=None, name="x"):
+    pass
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	diags, err := NewStateMutableDefault().Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
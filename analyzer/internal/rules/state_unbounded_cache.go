@@ -0,0 +1,357 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type StateUnboundedCache struct{}
+
+// newstateunboundedcache builds rule.
+func NewStateUnboundedCache() Rule { return StateUnboundedCache{} }
+
+func (StateUnboundedCache) ID() string { return "state.unbounded_cache" }
+
+func (StateUnboundedCache) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"state", "memory"},
+		Short:           "Cache grows without eviction",
+		Long:            "Module-level caches that only receive inserts leak memory in long-lived processes.",
+	}
+}
+
+func (StateUnboundedCache) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r StateUnboundedCache) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// collectionuse records how functions touch a module-level collection.
+type collectionUse struct {
+	inserts   []*sitter.Node
+	evictions []*sitter.Node
+	guarded   bool
+}
+
+var (
+	pyInsertMethods = []string{"append", "appendleft", "extend", "insert", "add", "update", "setdefault"}
+	pyEvictMethods  = []string{"pop", "popitem", "popleft", "clear", "remove", "discard"}
+	jsInsertMethods = []string{"set", "add", "push", "unshift"}
+	jsEvictMethods  = []string{"delete", "clear", "pop", "shift", "splice"}
+)
+
+func (r StateUnboundedCache) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var tracked []binding
+	for _, b := range pythonModuleBindings(ctx.Root, ctx.Source) {
+		if isMutableValue(b.value, ctx.Source, imports) && keywordArgumentValue(b.value, "maxlen", ctx.Source) == nil {
+			tracked = append(tracked, b)
+		}
+	}
+	uses := pythonCollectionUses(ctx.Root, tracked, ctx.Source)
+	diags := r.growthDiags(tracked, uses)
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "decorator" {
+			if expr := n.NamedChild(0); expr != nil && isUnboundedMemo(expr, ctx.Source, imports) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "Memoisation without a size limit",
+					Explanation: "functools.cache and lru_cache(maxsize=None) keep every distinct argument forever; use lru_cache(maxsize=N) or a TTL cache.",
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r StateUnboundedCache) runJS(ctx Context) []diagnostic.Diagnostic {
+	imports := jsImports(ctx.Root, ctx.Source)
+	var tracked []binding
+	for _, b := range jsModuleBindings(ctx.Root, ctx.Source) {
+		if isJSMutableValue(b.value, ctx.Source) {
+			tracked = append(tracked, b)
+		}
+	}
+	uses := jsCollectionUses(ctx.Root, tracked, ctx.Source)
+	diags := r.growthDiags(tracked, uses)
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call_expression" {
+			name := resolveImport(calleeName(n, ctx.Source), imports)
+			if matchesAny(name, "lodash.memoize", "lodash/memoize", "lodash.memoize.default", "_.memoize", "lodash-es.memoize") {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     "Memoisation without a size limit",
+					Explanation: "memoize keeps every result in an unbounded Map; use an LRU cache with a max size or clear memoized.cache periodically.",
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r StateUnboundedCache) growthDiags(tracked []binding, uses map[string]*collectionUse) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	seen := map[uint32]struct{}{}
+	for _, b := range tracked {
+		use := uses[b.name]
+		if use == nil || len(use.inserts) == 0 || len(use.evictions) > 0 || use.guarded {
+			continue
+		}
+		if _, ok := seen[b.decl.StartByte()]; ok {
+			continue
+		}
+		seen[b.decl.StartByte()] = struct{}{}
+		var related []diagnostic.Related
+		for _, site := range use.inserts {
+			related = append(related, diagnostic.Related{Message: "inserted here", Range: rangeFromNode(site)})
+		}
+		diags = append(diags, diagnostic.Diagnostic{
+			RuleID:      r.ID(),
+			Message:     "Module-level collection only grows",
+			Explanation: "Functions add to this collection but nothing removes entries or checks its size, so it grows for the life of the process. Use an LRU/TTL cache or evict explicitly.",
+			Range:       rangeFromNode(b.decl),
+			Related:     related,
+		})
+	}
+	return diags
+}
+
+// pythoncollectionuses finds inserts and evictions made inside functions.
+func pythonCollectionUses(root *sitter.Node, tracked []binding, source []byte) map[string]*collectionUse {
+	out := map[string]*collectionUse{}
+	for _, b := range tracked {
+		out[b.name] = &collectionUse{}
+	}
+	var walk func(n *sitter.Node, fn *sitter.Node)
+	walk = func(n *sitter.Node, fn *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "function_definition" {
+			fn = n
+		}
+		if fn != nil {
+			switch n.Type() {
+			case "call":
+				callee := n.ChildByFieldName("function")
+				if callee != nil && callee.Type() == "attribute" {
+					use := trackedUse(out, fn, content(source, callee.ChildByFieldName("object")), source)
+					method := content(source, callee.ChildByFieldName("attribute"))
+					if use != nil && matchesAny(method, pyInsertMethods...) {
+						use.inserts = append(use.inserts, n)
+					}
+					if use != nil && matchesAny(method, pyEvictMethods...) {
+						use.evictions = append(use.evictions, n)
+					}
+				}
+			case "assignment", "augmented_assignment":
+				left := n.ChildByFieldName("left")
+				if left != nil && left.Type() == "subscript" {
+					if use := trackedUse(out, fn, content(source, left.ChildByFieldName("value")), source); use != nil {
+						use.inserts = append(use.inserts, n)
+					}
+				}
+				if left != nil && left.Type() == "identifier" && declaresGlobal(fn, content(source, left), source) {
+					// global cache; cache = {} resets it.
+					if use := out[content(source, left)]; use != nil {
+						use.evictions = append(use.evictions, n)
+					}
+				}
+			case "delete_statement":
+				for i := 0; i < int(n.NamedChildCount()); i++ {
+					target := n.NamedChild(i)
+					if target != nil && target.Type() == "subscript" {
+						if use := trackedUse(out, fn, content(source, target.ChildByFieldName("value")), source); use != nil {
+							use.evictions = append(use.evictions, n)
+						}
+					}
+				}
+			case "if_statement", "while_statement":
+				markSizeGuard(out, n.ChildByFieldName("condition"), source)
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i), fn)
+		}
+	}
+	walk(root, nil)
+	return out
+}
+
+// trackeduse returns the use record unless fn shadows name with a local.
+func trackedUse(uses map[string]*collectionUse, fn *sitter.Node, name string, source []byte) *collectionUse {
+	use := uses[name]
+	if use == nil {
+		return nil
+	}
+	if fn.Type() == "function_definition" {
+		if declaresGlobal(fn, name, source) {
+			return use
+		}
+		_, params, body := functionParts(fn, source)
+		for _, p := range params {
+			if p == name {
+				return nil
+			}
+		}
+		for _, w := range assignmentsTo(body, name, source) {
+			if w.node.Type() == "assignment" {
+				return nil
+			}
+		}
+		return use
+	}
+	if _, local := jsLocalNames(fn, source)[name]; local {
+		return nil
+	}
+	return use
+}
+
+func declaresGlobal(fn *sitter.Node, name string, source []byte) bool {
+	found := false
+	walkScope(fn.ChildByFieldName("body"), func(n *sitter.Node) bool {
+		if n.Type() == "global_statement" {
+			for i := 0; i < int(n.NamedChildCount()); i++ {
+				if content(source, n.NamedChild(i)) == name {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// marksizeguard treats len(x) / x.size / x.length checks as a bound.
+func markSizeGuard(uses map[string]*collectionUse, cond *sitter.Node, source []byte) {
+	text := content(source, cond)
+	for name, use := range uses {
+		if strings.Contains(text, "len("+name+")") || strings.Contains(text, name+".size") || strings.Contains(text, name+".length") {
+			use.guarded = true
+		}
+	}
+}
+
+// jscollectionuses finds inserts and evictions made inside functions.
+func jsCollectionUses(root *sitter.Node, tracked []binding, source []byte) map[string]*collectionUse {
+	out := map[string]*collectionUse{}
+	for _, b := range tracked {
+		out[b.name] = &collectionUse{}
+	}
+	var walk func(n *sitter.Node, fn *sitter.Node)
+	walk = func(n *sitter.Node, fn *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if isFunctionNode(n) {
+			fn = n
+		}
+		if fn != nil {
+			switch n.Type() {
+			case "call_expression":
+				callee := n.ChildByFieldName("function")
+				if callee != nil && callee.Type() == "member_expression" {
+					use := trackedUse(out, fn, content(source, callee.ChildByFieldName("object")), source)
+					method := content(source, callee.ChildByFieldName("property"))
+					if use != nil && matchesAny(method, jsInsertMethods...) {
+						use.inserts = append(use.inserts, n)
+					}
+					if use != nil && matchesAny(method, jsEvictMethods...) {
+						use.evictions = append(use.evictions, n)
+					}
+				}
+			case "assignment_expression":
+				left := n.ChildByFieldName("left")
+				if left == nil {
+					break
+				}
+				switch left.Type() {
+				case "identifier":
+					if use := out[content(source, left)]; use != nil {
+						use.evictions = append(use.evictions, n)
+					}
+				case "subscript_expression", "member_expression":
+					use := trackedUse(out, fn, content(source, left.ChildByFieldName("object")), source)
+					if use == nil {
+						break
+					}
+					if content(source, left.ChildByFieldName("property")) == "length" {
+						use.evictions = append(use.evictions, n)
+					} else {
+						use.inserts = append(use.inserts, n)
+					}
+				}
+			case "unary_expression":
+				if strings.HasPrefix(content(source, n), "delete") {
+					if obj := rootObject(n.ChildByFieldName("argument")); obj != nil {
+						if use := trackedUse(out, fn, content(source, obj), source); use != nil {
+							use.evictions = append(use.evictions, n)
+						}
+					}
+				}
+			case "if_statement", "while_statement":
+				markSizeGuard(out, n.ChildByFieldName("condition"), source)
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i), fn)
+		}
+	}
+	walk(root, nil)
+	return out
+}
+
+// isunboundedmemo matches @cache and @lru_cache(maxsize=None).
+func isUnboundedMemo(expr *sitter.Node, source []byte, imports map[string]string) bool {
+	switch expr.Type() {
+	case "identifier", "attribute":
+		return resolveImport(content(source, expr), imports) == "functools.cache"
+	case "call":
+		name := resolveImport(calleeName(expr, source), imports)
+		if name != "functools.lru_cache" {
+			return false
+		}
+		size := keywordArgumentValue(expr, "maxsize", source)
+		if size == nil {
+			size = positionalArgument(expr, 0)
+		}
+		return size != nil && content(source, size) == "None"
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestStateUnboundedCachePythonInsertOnly(t *testing.T) {
+	src := []byte(`
+import functools
+_cache = {}
+_bounded = {}
+
+def get(key):
+    if key not in _cache:
+        _cache[key] = load(key)
+    return _cache[key]
+
+def get_bounded(key):
+    if len(_bounded) > 1000:
+        _bounded.clear()
+    _bounded[key] = load(key)
+
+@functools.lru_cache(maxsize=None)
+def expensive(x):
+    return x
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateUnboundedCache()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diags))
+	}
+}
+
+func TestStateUnboundedCacheJSMapWithDelete(t *testing.T) {
+	src := []byte(`
+const sessions = new Map();
+export function open(id, s) { sessions.set(id, s); }
+export function close(id) { sessions.delete(id); }
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewStateUnboundedCache()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %d", len(diags))
+	}
+}
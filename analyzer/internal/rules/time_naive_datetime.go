@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/diagnostic"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type TimeNaiveDatetime struct{}
+
+// newtimenaivedatetime builds rule.
+func NewTimeNaiveDatetime() Rule { return TimeNaiveDatetime{} }
+
+func (TimeNaiveDatetime) ID() string { return "time.naive_datetime" }
+
+func (TimeNaiveDatetime) Meta() Meta {
+	return Meta{
+		DefaultSeverity: "warning",
+		Tags:            []string{"reliability", "time"},
+		Short:           "Timezone-naive datetime",
+		Long:            "Naive datetimes and locale-dependent date parsing shift by hours around DST and region moves.",
+	}
+}
+
+func (TimeNaiveDatetime) Supports(language string) bool {
+	switch strings.ToLower(language) {
+	case "python", "javascript", "typescript":
+		return true
+	}
+	return false
+}
+
+func (r TimeNaiveDatetime) Run(ctx Context) ([]diagnostic.Diagnostic, error) {
+	switch strings.ToLower(ctx.Language) {
+	case "python":
+		return r.runPython(ctx), nil
+	case "javascript", "typescript":
+		return r.runJS(ctx), nil
+	default:
+		return nil, nil
+	}
+}
+
+// naivecall describes a datetime constructor and its tz-aware form.
+type naiveCall struct {
+	tzArg      int // positional index of tz, -1 when the call has none.
+	suggestion string
+}
+
+var pythonNaiveCalls = map[string]naiveCall{
+	"datetime.datetime.now":              {0, "datetime.now(timezone.utc)"},
+	"datetime.datetime.today":            {-1, "datetime.now(timezone.utc)"},
+	"datetime.datetime.utcnow":           {-1, "datetime.now(timezone.utc)"},
+	"datetime.datetime.fromtimestamp":    {1, "datetime.fromtimestamp(ts, tz=timezone.utc)"},
+	"datetime.datetime.utcfromtimestamp": {-1, "datetime.fromtimestamp(ts, tz=timezone.utc)"},
+}
+
+var isoDatePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?)?$`)
+
+func (r TimeNaiveDatetime) runPython(ctx Context) []diagnostic.Diagnostic {
+	imports := pythonImports(ctx.Root, ctx.Source)
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		if n.Type() == "call" {
+			written := calleeName(n, ctx.Source)
+			name := resolveImport(written, imports)
+			if c, ok := pythonNaiveCalls[name]; ok && !hasTZArgument(n, c.tzArg, ctx.Source) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     fmt.Sprintf("%s() returns a naive datetime", written),
+					Explanation: fmt.Sprintf("Naive datetimes carry no offset, so comparisons and arithmetic silently mix local time and UTC. Use %s (or ZoneInfo for a named zone).", c.suggestion),
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+func (r TimeNaiveDatetime) runJS(ctx Context) []diagnostic.Diagnostic {
+	var diags []diagnostic.Diagnostic
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+		var parsed *sitter.Node
+		switch n.Type() {
+		case "new_expression":
+			if content(ctx.Source, n.ChildByFieldName("constructor")) == "Date" {
+				if args := n.ChildByFieldName("arguments"); args != nil && args.NamedChildCount() == 1 {
+					parsed = args.NamedChild(0)
+				}
+			}
+		case "call_expression":
+			if calleeName(n, ctx.Source) == "Date.parse" {
+				parsed = positionalArgument(n, 0)
+			}
+		}
+		if parsed != nil && (parsed.Type() == "string" || parsed.Type() == "template_string") {
+			if value, ok := literalText(parsed, ctx.Source); ok && !isoDatePattern.MatchString(strings.TrimSpace(value)) {
+				diags = append(diags, diagnostic.Diagnostic{
+					RuleID:      r.ID(),
+					Message:     fmt.Sprintf("Non-ISO date string %q parsed by Date", value),
+					Explanation: "Only ISO 8601 strings have defined parsing; other formats are engine-specific and read as local time. Use an ISO string with an offset (2024-03-10T02:30:00Z) or Date.UTC(...).",
+					Range:       rangeFromNode(n),
+				})
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			walk(n.NamedChild(i))
+		}
+	}
+	walk(ctx.Root)
+	return diags
+}
+
+// hastzargument checks tz=... or the positional tz slot.
+func hasTZArgument(call *sitter.Node, idx int, source []byte) bool {
+	if idx < 0 {
+		return false
+	}
+	if v := keywordArgumentValue(call, "tz", source); v != nil {
+		return content(source, v) != "None"
+	}
+	if v := positionalArgument(call, idx); v != nil {
+		return content(source, v) != "None"
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/barthollomew/check-this.nvim/analyzer/internal/ts"
+)
+
+func TestTimeNaiveDatetimePython(t *testing.T) {
+	src := []byte(`
+import datetime as dt_mod
+from datetime import datetime as dt, timezone
+
+a = dt.now()
+b = dt.utcnow()
+c = dt_mod.datetime.fromtimestamp(ts)
+d = dt.now(timezone.utc)
+e = dt.fromtimestamp(ts, tz=timezone.utc)
+f = dt_mod.date.today()
+`)
+	root, err := ts.Parse("python", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewTimeNaiveDatetime()
+	diags, err := rule.Run(Context{Language: "python", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
+
+func TestTimeNaiveDatetimeJS(t *testing.T) {
+	src := []byte(`
+const a = new Date("03/10/2024 02:30");
+const b = Date.parse("March 10, 2024");
+const c = new Date("2024-03-10T02:30:00Z");
+const d = new Date(input);
+const e = new Date(2024, 2, 10);
+`)
+	root, err := ts.Parse("javascript", src)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rule := NewTimeNaiveDatetime()
+	diags, err := rule.Run(Context{Language: "javascript", Root: root, Source: src})
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(diags), diags)
+	}
+}
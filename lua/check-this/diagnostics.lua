@@ -36,6 +36,7 @@ function M.publish(bufnr, output, opts)
         explanation = d.explanation,
         rule_id = d.rule_id,
         tags = d.tags,
+        related = d.related,
       },
     })
   end
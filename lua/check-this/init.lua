@@ -28,6 +28,10 @@ local function explain_under_cursor(bufnr)
   if explanation ~= "" then
     table.insert(lines, explanation)
   end
+  local related = target.user_data and target.user_data.related or {}
+  for _, r in ipairs(related) do
+    table.insert(lines, string.format("  line %d: %s", r.range.start.line + 1, r.message))
+  end
   vim.notify(table.concat(lines, "\n"), vim.log.levels.INFO)
 end
 